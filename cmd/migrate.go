@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/x/term"
+	"github.com/grammeaway/lazyhetzner/internal/config"
+	"github.com/grammeaway/lazyhetzner/internal/secret"
+)
+
+const migrateUsage = `Usage:
+  lazyhetzner migrate-tokens vault
+  lazyhetzner migrate-tokens command --store "pass insert -m -f hcloud/{project}" --get "pass show hcloud/{project}"
+
+Moves the plaintext API tokens in config.json into the encrypted token vault, or into an external
+command. {project} is replaced by the quoted project name.
+`
+
+// migrateTokens runs the migrate-tokens command, which moves plaintext tokens into a token backend
+func migrateTokens(args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	var backend string
+	var store secret.Store
+	switch args[0] {
+	case config.TokenBackendVault:
+		vault, err := openVault()
+		if err != nil {
+			return err
+		}
+		backend, store = config.TokenBackendVault, vault
+	case config.TokenBackendCommand:
+		flags := flag.NewFlagSet("migrate-tokens command", flag.ContinueOnError)
+		storeTemplate := flags.String("store", "", "command keeping the token given on its standard input")
+		getTemplate := flags.String("get", "", "command printing the token")
+		if err := flags.Parse(args[1:]); err != nil {
+			return err
+		}
+		if *storeTemplate == "" || *getTemplate == "" {
+			return errors.New(migrateUsage)
+		}
+		backend, store = config.TokenBackendCommand, secret.Command{StoreTemplate: *storeTemplate, GetTemplate: *getTemplate}
+	default:
+		return errors.New(migrateUsage)
+	}
+
+	moved, err := config.MigrateTokens(context.Background(), backend, store)
+	for _, name := range moved {
+		fmt.Printf("Moved the token of %s into the %s backend\n", name, backend)
+	}
+	if err != nil {
+		return err
+	}
+	if len(moved) == 0 {
+		fmt.Println("There are no plaintext tokens to move")
+	}
+	return nil
+}
+
+// openVault asks for the passphrase of the token vault, twice if the vault is created
+func openVault() (*secret.Vault, error) {
+	path, err := config.GetVaultPath()
+	if err != nil {
+		return nil, err
+	}
+	if secret.VaultExists(path) {
+		passphrase, err := readPassphrase("Passphrase of the token vault: ")
+		if err != nil {
+			return nil, err
+		}
+		return secret.OpenVault(path, passphrase)
+	}
+
+	fmt.Printf("Creating the token vault at %s\n", path)
+	passphrase, err := readPassphrase("New passphrase: ")
+	if err != nil {
+		return nil, err
+	}
+	confirmation, err := readPassphrase("Repeat the passphrase: ")
+	if err != nil {
+		return nil, err
+	}
+	if passphrase != confirmation {
+		return nil, errors.New("the passphrases don't match")
+	}
+	return secret.OpenVault(path, passphrase)
+}
+
+func readPassphrase(prompt string) (string, error) {
+	if !term.IsTerminal(os.Stdin.Fd()) {
+		return "", errors.New("the vault passphrase can only be entered in a terminal")
+	}
+	fmt.Print(prompt)
+	passphrase, err := term.ReadPassword(os.Stdin.Fd())
+	fmt.Println()
+	if err != nil {
+		return "", err
+	}
+	// The passphrase is used as typed, exactly like on the unlock screen
+	return string(passphrase), nil
+}
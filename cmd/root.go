@@ -49,6 +49,13 @@ func App() {
 		fmt.Printf("awsbreeze version: %s\ncommit: %s\nbuilt at: %s\n", version, commit, date)
 		return
 	}
+	if len(os.Args) > 1 && os.Args[1] == "migrate-tokens" {
+		if err := migrateTokens(os.Args[2:]); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
 
 	p := tea.NewProgram(initialModel(), tea.WithAltScreen())
 	if _, err := p.Run(); err != nil {
@@ -0,0 +1,165 @@
+// Package audit keeps an append-only log of the write operations performed through lazyhetzner,
+// stored as one JSON object per line next to the config file.
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/grammeaway/lazyhetzner/internal/config"
+)
+
+const fileName = "audit.jsonl"
+
+// Results of an operation
+const (
+	ResultSuccess = "success"
+	ResultError   = "error"
+)
+
+// Entry records a single operation on a single resource. It never holds the project's token.
+type Entry struct {
+	Time    time.Time `json:"time"`
+	Project string    `json:"project"`
+	// ResourceType is the config key of the resource type, like "servers"
+	ResourceType string            `json:"resource_type"`
+	ResourceID   int64             `json:"resource_id"`
+	ResourceName string            `json:"resource_name,omitempty"`
+	Action       string            `json:"action"`
+	Params       map[string]string `json:"params,omitempty"`
+	Result       string            `json:"result"`
+	Error        string            `json:"error,omitempty"`
+}
+
+// Serializes appends, as bulk actions finish concurrently
+var mu sync.Mutex
+
+// Path returns the location of the audit log
+func Path() (string, error) {
+	configDir, err := config.GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, fileName), nil
+}
+
+// Append adds entries to the end of the audit log
+func Append(entries ...Entry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	var lines []byte
+	for _, entry := range entries {
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		lines = append(append(lines, line...), '\n')
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(lines); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// Load reads the audit log, newest entry first. Lines that can't be parsed are skipped,
+// so a log cut off by a crash stays readable.
+func Load() ([]Entry, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	slices.Reverse(entries)
+	return entries, nil
+}
+
+type LoadedMsg struct {
+	Entries []Entry
+	Err     error
+}
+
+func LoadCmd() tea.Cmd {
+	return func() tea.Msg {
+		entries, err := Load()
+		return LoadedMsg{Entries: entries, Err: err}
+	}
+}
+
+// Filter selects entries by project and resource
+type Filter struct {
+	// ByProject limits entries to Project, which is empty for one-time token access
+	ByProject bool
+	Project   string
+	// Resource matches the resource type, name or ID, ignoring case. Empty matches every resource.
+	Resource string
+}
+
+func (f Filter) Matches(entry Entry) bool {
+	if f.ByProject && entry.Project != f.Project {
+		return false
+	}
+	query := strings.ToLower(strings.TrimSpace(f.Resource))
+	if query == "" {
+		return true
+	}
+	for _, value := range []string{entry.ResourceType, entry.ResourceName, strconv.FormatInt(entry.ResourceID, 10)} {
+		if strings.Contains(strings.ToLower(value), query) {
+			return true
+		}
+	}
+	return false
+}
+
+// Projects returns the projects of the entries, sorted by name
+func Projects(entries []Entry) []string {
+	var projects []string
+	for _, entry := range entries {
+		if !slices.Contains(projects, entry.Project) {
+			projects = append(projects, entry.Project)
+		}
+	}
+	slices.Sort(projects)
+	return projects
+}
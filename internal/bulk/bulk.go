@@ -0,0 +1,337 @@
+package bulk
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/grammeaway/lazyhetzner/internal/audit"
+	ctm "github.com/grammeaway/lazyhetzner/internal/context_menu"
+	"github.com/grammeaway/lazyhetzner/internal/message"
+	"github.com/grammeaway/lazyhetzner/internal/registry"
+	"github.com/grammeaway/lazyhetzner/internal/resource"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+// Maximum number of API calls a bulk action keeps in flight at once
+const maxConcurrency = 4
+
+// Target is a single marked resource a bulk action is run against
+type Target struct {
+	ResourceType resource.ResourceType
+	ID           int64
+	Name         string
+	// Project is the name of the project the target belongs to, recorded in the audit log
+	Project string
+	// Client overrides the default client, e.g. for targets from another project
+	Client *hcloud.Client
+	// FromAllProjects is set for targets marked in the all-projects view, which are listed with their project
+	FromAllProjects bool
+}
+
+// DisplayName returns the name the target is listed with
+func (t Target) DisplayName() string {
+	if t.FromAllProjects {
+		return fmt.Sprintf("[%s] %s", t.Project, t.Name)
+	}
+	return t.Name
+}
+
+func (t Target) getClient(defaultClient *hcloud.Client) *hcloud.Client {
+	if t.Client != nil {
+		return t.Client
+	}
+	return defaultClient
+}
+
+// Result holds the outcome of a bulk action for one target
+type Result struct {
+	Target Target
+	Err    error
+}
+
+type BulkActionCompletedMsg struct {
+	ResourceType resource.ResourceType
+	Action       string
+	Param        string
+	Results      []Result
+	// AuditErr is set if the results could not be recorded in the audit log
+	AuditErr error
+}
+
+// Failed returns the targets whose action returned an error
+func (msg BulkActionCompletedMsg) Failed() []Target {
+	failed := []Target{}
+	for _, result := range msg.Results {
+		if result.Err != nil {
+			failed = append(failed, result.Target)
+		}
+	}
+	return failed
+}
+
+// GetBulkMenuItems returns the bulk menu items of a resource type, or none if its resources can't be marked
+func GetBulkMenuItems(rt resource.ResourceType) []ctm.ContextMenuItem {
+	_, bulkKind, ok := registry.GetBulkKind(rt)
+	if !ok {
+		return nil
+	}
+	items := []ctm.ContextMenuItem{
+		{Label: "❌ Cancel", Action: "cancel"},
+		{Label: "🏷️  Add Label", Action: "add_label", Mutating: true},
+		{Label: "🏷️  Remove Label", Action: "remove_label", Mutating: true},
+	}
+	for _, action := range bulkKind.BulkActions() {
+		items = append(items, action.Item)
+	}
+	return append(items, ctm.ContextMenuItem{Label: "🗑️  Delete", Action: "delete", Mutating: true, Destructive: true})
+}
+
+// findKindAction returns a bulk action of a resource type's kind
+func findKindAction(rt resource.ResourceType, action string) (registry.BulkAction, bool) {
+	_, bulkKind, ok := registry.GetBulkKind(rt)
+	if !ok {
+		return registry.BulkAction{}, false
+	}
+	for _, bulkAction := range bulkKind.BulkActions() {
+		if bulkAction.Item.Action == action {
+			return bulkAction, true
+		}
+	}
+	return registry.BulkAction{}, false
+}
+
+// GetActionLabel returns a human readable name for a bulk action
+func GetActionLabel(action string) string {
+	switch action {
+	case "add_label":
+		return "Add label"
+	case "remove_label":
+		return "Remove label"
+	case "delete":
+		return "Delete"
+	}
+	// The audit log lists actions of every kind, so the kind of an action isn't always at hand
+	for _, kind := range registry.Kinds() {
+		if bulkAction, ok := findKindAction(kind.Type(), action); ok {
+			return bulkAction.Name
+		}
+	}
+	return action
+}
+
+// GetInputPrompt returns the prompt for actions that need a parameter or a confirmation, or an empty string.
+// Destructive actions, like deleting or shutting servers down, ask to confirm how many resources they affect.
+func GetInputPrompt(rt resource.ResourceType, action string, count int) string {
+	switch action {
+	case "add_label":
+		return "Label to add (key=value):"
+	case "remove_label":
+		return "Label key to remove:"
+	case "delete":
+		return fmt.Sprintf("Type 'delete' to confirm deleting %d resource(s):", count)
+	}
+	bulkAction, _ := findKindAction(rt, action)
+	if bulkAction.Item.Destructive {
+		return fmt.Sprintf("Type %d to confirm '%s' on %d resource(s):", count, strings.ToLower(bulkAction.Name), count)
+	}
+	return bulkAction.Prompt
+}
+
+// ValidateParam checks the parameter entered for an action on count resources before anything is sent to the API
+func ValidateParam(rt resource.ResourceType, action string, param string, count int) error {
+	switch action {
+	case "add_label":
+		if _, _, err := parseLabel(param); err != nil {
+			return err
+		}
+	case "remove_label":
+		if param == "" {
+			return fmt.Errorf("a value is required")
+		}
+	case "delete":
+		if param != "delete" {
+			return fmt.Errorf("deletion not confirmed")
+		}
+	default:
+		bulkAction, ok := findKindAction(rt, action)
+		switch {
+		case !ok:
+		case bulkAction.Item.Destructive:
+			if param != strconv.Itoa(count) {
+				return fmt.Errorf("%s not confirmed, type %d", strings.ToLower(bulkAction.Name), count)
+			}
+		case bulkAction.Prompt != "" && param == "":
+			return fmt.Errorf("a value is required")
+		}
+	}
+	return nil
+}
+
+func parseLabel(param string) (string, string, error) {
+	key, value, found := strings.Cut(param, "=")
+	key = strings.TrimSpace(key)
+	if !found || key == "" {
+		return "", "", fmt.Errorf("label must be in the form key=value")
+	}
+	return key, strings.TrimSpace(value), nil
+}
+
+// RunBulkAction executes the action against all targets with bounded concurrency
+func RunBulkAction(ctx context.Context, client *hcloud.Client, rt resource.ResourceType, action string, param string, targets []Target) tea.Cmd {
+	return func() tea.Msg {
+		_, bulkKind, ok := registry.GetBulkKind(rt)
+		if !ok {
+			return message.ErrorMsg{Err: fmt.Errorf("bulk actions aren't available for %s", resource.GetResourceNameFromType(rt))}
+		}
+
+		// Parameters are resolved once per client, since targets may belong to different projects
+		params := make(map[*hcloud.Client]string)
+		paramErrs := make(map[*hcloud.Client]error)
+		if kindAction, ok := findKindAction(rt, action); ok && kindAction.Resolve != nil {
+			for _, target := range targets {
+				targetClient := target.getClient(client)
+				if _, resolved := params[targetClient]; resolved || paramErrs[targetClient] != nil {
+					continue
+				}
+				resolved, err := kindAction.Resolve(ctx, targetClient, param)
+				if err != nil {
+					paramErrs[targetClient] = err
+					continue
+				}
+				params[targetClient] = resolved
+			}
+			if len(params) == 0 {
+				for _, err := range paramErrs {
+					return message.ErrorMsg{Err: err}
+				}
+			}
+		}
+
+		results := make([]Result, len(targets))
+		sem := make(chan struct{}, maxConcurrency)
+		var (
+			wg       sync.WaitGroup
+			auditMu  sync.Mutex
+			auditErr error
+		)
+		for i, target := range targets {
+			wg.Add(1)
+			go func(i int, target Target) {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+				targetClient := target.getClient(client)
+				result := Result{Target: target, Err: paramErrs[targetClient]}
+				if result.Err == nil {
+					targetParam, resolved := params[targetClient]
+					if !resolved {
+						targetParam = param
+					}
+					result.Err = executeAction(ctx, targetClient, bulkKind, rt, action, targetParam, target)
+				}
+				results[i] = result
+
+				// Every result is recorded as soon as it is known, so the audit log keeps the changes
+				// made before a long run is interrupted
+				if err := audit.Append(getAuditEntry(rt, action, param, result)); err != nil {
+					auditMu.Lock()
+					if auditErr == nil {
+						auditErr = err
+					}
+					auditMu.Unlock()
+				}
+			}(i, target)
+		}
+		wg.Wait()
+
+		return BulkActionCompletedMsg{
+			ResourceType: rt,
+			Action:       action,
+			Param:        param,
+			Results:      results,
+			AuditErr:     auditErr,
+		}
+	}
+}
+
+// getAuditEntry records the outcome of an action for a single target
+func getAuditEntry(rt resource.ResourceType, action string, param string, result Result) audit.Entry {
+	entry := audit.Entry{
+		Time:         time.Now(),
+		Project:      result.Target.Project,
+		ResourceType: resource.GetResourceKeyFromType(result.Target.ResourceType),
+		ResourceID:   result.Target.ID,
+		ResourceName: result.Target.Name,
+		Action:       action,
+		Params:       getAuditParams(rt, action, param),
+		Result:       audit.ResultSuccess,
+	}
+	if result.Err != nil {
+		entry.Result, entry.Error = audit.ResultError, result.Err.Error()
+	}
+	return entry
+}
+
+// getAuditParams names the parameter of an action for the audit log. The confirmation typed
+// for deletes is no parameter of the operation, so it isn't recorded.
+func getAuditParams(rt resource.ResourceType, action string, param string) map[string]string {
+	switch action {
+	case "add_label":
+		return map[string]string{"label": param}
+	case "remove_label":
+		return map[string]string{"key": param}
+	}
+	if kindAction, ok := findKindAction(rt, action); ok && kindAction.ParamName != "" {
+		return map[string]string{kindAction.ParamName: param}
+	}
+	return nil
+}
+
+func executeAction(ctx context.Context, client *hcloud.Client, kind registry.BulkKind, rt resource.ResourceType, action string, param string, target Target) error {
+	switch action {
+	case "add_label":
+		key, value, err := parseLabel(param)
+		if err != nil {
+			return err
+		}
+		// Labels are replaced as a whole, so they are fetched right before the update instead of
+		// using the loaded list, which may miss labels changed since
+		labels, err := kind.GetLabels(ctx, client, target.ID)
+		if err != nil {
+			return err
+		}
+		labels = copyLabels(labels)
+		labels[key] = value
+		return kind.UpdateLabels(ctx, client, target.ID, labels)
+	case "remove_label":
+		labels, err := kind.GetLabels(ctx, client, target.ID)
+		if err != nil {
+			return err
+		}
+		if _, ok := labels[param]; !ok {
+			return nil
+		}
+		labels = copyLabels(labels)
+		delete(labels, param)
+		return kind.UpdateLabels(ctx, client, target.ID, labels)
+	case "delete":
+		return kind.Delete(ctx, client, target.ID)
+	}
+	if kindAction, ok := findKindAction(rt, action); ok {
+		return kindAction.Run(ctx, client, target.ID, param)
+	}
+	return fmt.Errorf("unknown bulk action: %s", action)
+}
+
+func copyLabels(labels map[string]string) map[string]string {
+	copied := make(map[string]string, len(labels)+1)
+	for key, value := range labels {
+		copied[key] = value
+	}
+	return copied
+}
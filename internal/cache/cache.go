@@ -0,0 +1,52 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+// Entry is a cached value along with the time it was loaded
+type Entry[V any] struct {
+	Value    V
+	LoadedAt time.Time
+}
+
+// Age returns how long ago the entry was loaded
+func (e Entry[V]) Age() time.Duration {
+	return time.Since(e.LoadedAt)
+}
+
+// Store is a timestamped cache that is safe to use from concurrent commands
+type Store[K comparable, V any] struct {
+	mu      sync.RWMutex
+	entries map[K]Entry[V]
+}
+
+func NewStore[K comparable, V any]() *Store[K, V] {
+	return &Store[K, V]{entries: make(map[K]Entry[V])}
+}
+
+func (s *Store[K, V]) Get(key K) (Entry[V], bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entry, ok := s.entries[key]
+	return entry, ok
+}
+
+func (s *Store[K, V]) Set(key K, value V) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[key] = Entry[V]{Value: value, LoadedAt: time.Now()}
+}
+
+func (s *Store[K, V]) Invalidate(key K) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, key)
+}
+
+func (s *Store[K, V]) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = make(map[K]Entry[V])
+}
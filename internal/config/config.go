@@ -6,27 +6,81 @@ import (
 	"github.com/grammeaway/lazyhetzner/internal/message"
 	"os"
 	"path/filepath"
+	"time"
 )
 
 // Config management
 type ProjectConfig struct {
-	Name  string `json:"name"`
-	Token string `json:"token"`
+	Name string `json:"name"`
+	// Token is kept in config.json only for the plaintext backend. For other backends it holds the token
+	// read from them, which is never written back.
+	Token string `json:"token,omitempty"`
+	// TokenBackend is where the token is kept, TokenBackendPlaintext if empty
+	TokenBackend string `json:"token_backend,omitempty"`
+	// TokenRef tells the backend which token to read: the vault entry, or the command to run
+	TokenRef string `json:"token_ref,omitempty"`
+	// ReadOnly hides and refuses every action that changes resources of the project
+	ReadOnly bool `json:"read_only,omitempty"`
+	// ConfirmLevel set to ConfirmLevelStrict protects the project: destructive actions need its name typed
+	// to go ahead, and a banner marks it as production
+	ConfirmLevel string `json:"confirm_level,omitempty"`
+}
+
+// ConfirmLevelStrict marks a protected project
+const ConfirmLevelStrict = "strict"
+
+// IsProtected reports whether destructive actions on the project need its name typed to go ahead
+func (p ProjectConfig) IsProtected() bool {
+	return p.ConfirmLevel == ConfirmLevelStrict
 }
 
 type Config struct {
 	Projects        []ProjectConfig `json:"projects"`
 	DefaultProject  string          `json:"default_project"`
 	DefaultTerminal string          `json:"default_terminal"`
+	// AutoRefresh holds the auto-refresh interval in seconds per tab, keyed by resource type
+	AutoRefresh map[string]int `json:"auto_refresh,omitempty"`
+	// RequestTimeout bounds resource loads and lookups, in seconds
+	RequestTimeout int `json:"request_timeout,omitempty"`
+	// ActionTimeout bounds actions like power changes, including waiting for them to finish, in seconds
+	ActionTimeout int `json:"action_timeout,omitempty"`
+	// Tables holds the table mode layout per tab, keyed by resource type
+	Tables map[string]TableLayout `json:"tables,omitempty"`
+	// Sort holds the sort order per tab, keyed by resource type
+	Sort map[string]SortOrder `json:"sort,omitempty"`
+	// HidePreview hides the pane next to the resource list that previews the selected resource
+	HidePreview bool `json:"hide_preview,omitempty"`
+}
+
+// TableLayout is the layout of a tab shown as a table
+type TableLayout struct {
+	Enabled bool `json:"enabled"`
+	// Hidden lists the keys of the columns that aren't shown
+	Hidden []string `json:"hidden,omitempty"`
+	// Widths overrides the default width of columns, keyed by column key
+	Widths map[string]int `json:"widths,omitempty"`
+}
+
+// SortOrder is the field a tab is sorted by. An empty field keeps the order of the API.
+type SortOrder struct {
+	Field      string `json:"field"`
+	Descending bool   `json:"descending,omitempty"`
 }
 
+// Timeouts used when the config doesn't set them
+const (
+	DefaultRequestTimeout = 30 * time.Second
+	DefaultActionTimeout  = 5 * time.Minute
+)
+
 type ConfigLoadedMsg struct {
 	Config *Config
 }
 
 type ProjectSavedMsg struct{}
 
-func getConfigPath() (string, error) {
+// GetConfigDir returns the lazyhetzner directory in the user's config dir, creating it if needed
+func GetConfigDir() (string, error) {
 	configDir, err := os.UserConfigDir()
 	if err != nil {
 		return "", err
@@ -36,8 +90,16 @@ func getConfigPath() (string, error) {
 	if err := os.MkdirAll(configDirComplete, os.ModePerm); err != nil {
 		return "", err
 	}
+	return configDirComplete, nil
+}
 
-	return filepath.Join(configDirComplete, "config.json"), nil
+func getConfigPath() (string, error) {
+	configDir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDir, "config.json"), nil
 }
 
 func loadConfig() (*Config, error) {
@@ -76,24 +138,31 @@ func saveConfig(config *Config) error {
 	return os.WriteFile(configPath, data, 0600)
 }
 
-func (c *Config) AddProject(name, token string) {
-	// Remove existing project with same name
+// AddProject adds a project with a plaintext token. A project of the same name is replaced, keeping its
+// protection, and returned so the caller can tell where its token was kept.
+func (c *Config) AddProject(name, token string) *ProjectConfig {
+	project := ProjectConfig{
+		Name:  name,
+		Token: token,
+	}
+	var replaced *ProjectConfig
+	// Remove existing project with same name, keeping its protection
 	for i, p := range c.Projects {
 		if p.Name == name {
+			replaced = &p
+			project.ReadOnly, project.ConfirmLevel = p.ReadOnly, p.ConfirmLevel
 			c.Projects = append(c.Projects[:i], c.Projects[i+1:]...)
 			break
 		}
 	}
 
-	c.Projects = append(c.Projects, ProjectConfig{
-		Name:  name,
-		Token: token,
-	})
+	c.Projects = append(c.Projects, project)
 
 	// Set as default if it's the first project
 	if len(c.Projects) == 1 {
 		c.DefaultProject = name
 	}
+	return replaced
 }
 
 func (c *Config) GetProject(name string) *ProjectConfig {
@@ -117,11 +186,74 @@ func (c *Config) RemoveProject(name string) {
 	}
 }
 
+// GetAutoRefreshInterval returns the auto-refresh interval of a tab, or 0 if auto-refresh is off
+func (c *Config) GetAutoRefreshInterval(tab string) time.Duration {
+	return time.Duration(c.AutoRefresh[tab]) * time.Second
+}
+
+func (c *Config) SetAutoRefreshInterval(tab string, interval time.Duration) {
+	if c.AutoRefresh == nil {
+		c.AutoRefresh = make(map[string]int)
+	}
+	if interval <= 0 {
+		delete(c.AutoRefresh, tab)
+		return
+	}
+	c.AutoRefresh[tab] = int(interval / time.Second)
+}
+
+// GetTableLayout returns the table layout of a tab. Tabs without one are shown as a list.
+func (c *Config) GetTableLayout(tab string) TableLayout {
+	if c == nil {
+		return TableLayout{}
+	}
+	return c.Tables[tab]
+}
+
+func (c *Config) SetTableLayout(tab string, layout TableLayout) {
+	if c.Tables == nil {
+		c.Tables = make(map[string]TableLayout)
+	}
+	c.Tables[tab] = layout
+}
+
+func (c *Config) GetSortOrder(tab string) SortOrder {
+	if c == nil {
+		return SortOrder{}
+	}
+	return c.Sort[tab]
+}
+
+func (c *Config) SetSortOrder(tab string, order SortOrder) {
+	if c.Sort == nil {
+		c.Sort = make(map[string]SortOrder)
+	}
+	if order.Field == "" {
+		delete(c.Sort, tab)
+		return
+	}
+	c.Sort[tab] = order
+}
+
+func (c *Config) GetRequestTimeout() time.Duration {
+	if c == nil || c.RequestTimeout <= 0 {
+		return DefaultRequestTimeout
+	}
+	return time.Duration(c.RequestTimeout) * time.Second
+}
+
+func (c *Config) GetActionTimeout() time.Duration {
+	if c == nil || c.ActionTimeout <= 0 {
+		return DefaultActionTimeout
+	}
+	return time.Duration(c.ActionTimeout) * time.Second
+}
+
 func LoadConfigCmd() tea.Cmd {
 	return func() tea.Msg {
 		config, err := loadConfig()
 		if err != nil {
-			return message.ErrorMsg{err}
+			return message.ErrorMsg{Err: err}
 		}
 		return ConfigLoadedMsg{config}
 	}
@@ -130,7 +262,7 @@ func LoadConfigCmd() tea.Cmd {
 func SaveConfigCmd(config *Config) tea.Cmd {
 	return func() tea.Msg {
 		if err := saveConfig(config); err != nil {
-			return message.ErrorMsg{err}
+			return message.ErrorMsg{Err: err}
 		}
 		return ProjectSavedMsg{}
 	}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/grammeaway/lazyhetzner/internal/secret"
+)
+
+func TestAddProject(t *testing.T) {
+	existing := []ProjectConfig{
+		{Name: "production", TokenBackend: TokenBackendVault, TokenRef: "production", Token: "old-token", ReadOnly: true},
+		{Name: "ops", TokenBackend: TokenBackendCommand, TokenRef: "pass show ops", Token: "old-token", ConfirmLevel: ConfirmLevelStrict},
+		{Name: "staging", Token: "old-token"},
+	}
+	tests := []struct {
+		name         string
+		project      string
+		wantReplaced string
+	}{
+		{name: "new project", project: "dev"},
+		{name: "re-added plaintext project", project: "staging", wantReplaced: TokenBackendPlaintext},
+		{name: "re-added vault project", project: "production", wantReplaced: TokenBackendVault},
+		{name: "re-added command project", project: "ops", wantReplaced: TokenBackendCommand},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &Config{Projects: append([]ProjectConfig(nil), existing...)}
+			replaced := c.AddProject(test.project, "new-token")
+
+			switch {
+			case test.wantReplaced == "" && replaced != nil:
+				t.Errorf("replaced %s, want no replaced project", replaced.Name)
+			case test.wantReplaced != "" && replaced == nil:
+				t.Errorf("no replaced project, want the %s backend", test.wantReplaced)
+			case replaced != nil && replaced.GetTokenBackend() != test.wantReplaced:
+				t.Errorf("replaced project used the %s backend, want %s", replaced.GetTokenBackend(), test.wantReplaced)
+			}
+
+			count := 0
+			for _, project := range c.Projects {
+				if project.Name == test.project {
+					count++
+				}
+			}
+			if count != 1 {
+				t.Fatalf("%d projects named %s, want 1", count, test.project)
+			}
+			project := c.GetProject(test.project)
+			if project.GetTokenBackend() != TokenBackendPlaintext || project.TokenRef != "" || project.Token != "new-token" {
+				t.Errorf("token = %q from %s %q, want the new plaintext token", project.Token, project.GetTokenBackend(), project.TokenRef)
+			}
+			if replaced != nil && (project.ReadOnly != replaced.ReadOnly || project.ConfirmLevel != replaced.ConfirmLevel) {
+				t.Errorf("%s lost its protection settings", project.Name)
+			}
+		})
+	}
+}
+
+func TestAddProjectReplacesVaultToken(t *testing.T) {
+	vault, err := secret.OpenVault(filepath.Join(t.TempDir(), "tokens.vault"), "secret")
+	if err != nil {
+		t.Fatalf("creating the vault: %v", err)
+	}
+	ref, err := vault.Set(context.Background(), "production", "old-token")
+	if err != nil {
+		t.Fatalf("storing the old token: %v", err)
+	}
+	c := &Config{Projects: []ProjectConfig{{Name: "production", TokenBackend: TokenBackendVault, TokenRef: ref}}}
+
+	// Re-adding a project with the vault unlocked writes the new token over its old vault entry
+	c.AddProject("production", "new-token")
+	if err := c.MoveToken(context.Background(), "production", TokenBackendVault, vault); err != nil {
+		t.Fatalf("moving the new token into the vault: %v", err)
+	}
+	project := c.GetProject("production")
+	if project.GetTokenBackend() != TokenBackendVault || project.TokenRef != ref {
+		t.Errorf("token source = %s %q, want the vault entry %q", project.GetTokenBackend(), project.TokenRef, ref)
+	}
+	if token, err := vault.Get(context.Background(), ref); err != nil || token != "new-token" {
+		t.Errorf("vault token = %q, %v, want the new token", token, err)
+	}
+}
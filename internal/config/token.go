@@ -0,0 +1,192 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/grammeaway/lazyhetzner/internal/secret"
+)
+
+// Backends the token of a project is kept in
+const (
+	// TokenBackendPlaintext keeps the token in config.json itself
+	TokenBackendPlaintext = "plaintext"
+	// TokenBackendVault keeps the token in the vault file, encrypted with a passphrase asked for at startup
+	TokenBackendVault = "vault"
+	// TokenBackendCommand reads the token from the output of a command, like a password manager's
+	TokenBackendCommand = "command"
+)
+
+const vaultFileName = "tokens.vault"
+
+// ErrVaultLocked is the error of projects keeping their token in the vault when it was skipped at startup
+var ErrVaultLocked = errors.New("the token vault wasn't unlocked")
+
+// GetVaultPath returns the location of the token vault
+func GetVaultPath() (string, error) {
+	configDir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, vaultFileName), nil
+}
+
+// GetTokenBackend returns the backend the token of the project is kept in
+func (p ProjectConfig) GetTokenBackend() string {
+	if p.TokenBackend == "" {
+		return TokenBackendPlaintext
+	}
+	return p.TokenBackend
+}
+
+// MarshalJSON leaves out tokens read from a backend, so they never end up in config.json
+func (p ProjectConfig) MarshalJSON() ([]byte, error) {
+	type projectConfig ProjectConfig
+	if p.GetTokenBackend() != TokenBackendPlaintext {
+		p.Token = ""
+	}
+	return json.Marshal(projectConfig(p))
+}
+
+// UsesTokenBackend reports whether any project keeps its token in the backend
+func (c *Config) UsesTokenBackend(backend string) bool {
+	return slices.ContainsFunc(c.Projects, func(p ProjectConfig) bool {
+		return p.GetTokenBackend() == backend
+	})
+}
+
+// UsesTokenBackends reports whether any project keeps its token outside of config.json
+func (c *Config) UsesTokenBackends() bool {
+	return slices.ContainsFunc(c.Projects, func(p ProjectConfig) bool {
+		return p.GetTokenBackend() != TokenBackendPlaintext
+	})
+}
+
+// SetTokens sets the tokens read from backends, keyed by project name
+func (c *Config) SetTokens(tokens map[string]string) {
+	for i, p := range c.Projects {
+		if token, exists := tokens[p.Name]; exists && p.GetTokenBackend() != TokenBackendPlaintext {
+			c.Projects[i].Token = token
+		}
+	}
+}
+
+// MoveToken moves the plaintext token of a project into a store, keeping the reference to read it back with.
+// The token stays available until the app exits, but is no longer written to config.json.
+func (c *Config) MoveToken(ctx context.Context, name, backend string, store secret.Store) error {
+	for i, p := range c.Projects {
+		if p.Name != name {
+			continue
+		}
+		if p.GetTokenBackend() != TokenBackendPlaintext {
+			return fmt.Errorf("the token of %s is already kept in the %s backend", name, p.GetTokenBackend())
+		}
+		ref, err := store.Set(ctx, p.Name, p.Token)
+		if err != nil {
+			return fmt.Errorf("storing the token of %s: %w", name, err)
+		}
+		c.Projects[i].TokenBackend, c.Projects[i].TokenRef = backend, ref
+		return nil
+	}
+	return fmt.Errorf("no project named %s", name)
+}
+
+// MigrateTokens moves every plaintext token in config.json into a store and returns the projects moved.
+// Projects moved before a failure are still saved.
+func MigrateTokens(ctx context.Context, backend string, store secret.Store) ([]string, error) {
+	config, err := loadConfig()
+	if err != nil {
+		return nil, err
+	}
+	var moved []string
+	var moveErr error
+	for _, project := range config.Projects {
+		if project.GetTokenBackend() != TokenBackendPlaintext || project.Token == "" {
+			continue
+		}
+		if moveErr = config.MoveToken(ctx, project.Name, backend, store); moveErr != nil {
+			break
+		}
+		moved = append(moved, project.Name)
+	}
+	if len(moved) == 0 {
+		return nil, moveErr
+	}
+	if err := saveConfig(config); err != nil {
+		return nil, err
+	}
+	return moved, moveErr
+}
+
+// TokensResolvedMsg holds the tokens read from the backends of the projects
+type TokensResolvedMsg struct {
+	// Vault is the unlocked vault, nil if no project uses it
+	Vault *secret.Vault
+	// Tokens holds the tokens read, keyed by project name
+	Tokens map[string]string
+	// Errors holds why tokens couldn't be read, keyed by project name
+	Errors map[string]error
+	// UnlockErr is set if the vault couldn't be unlocked, in which case no token was read
+	UnlockErr error
+}
+
+// ResolveTokensCmd reads the tokens of the projects that keep them in a backend, one after another so that
+// password managers don't ask for unlocking several times at once. The passphrase unlocks the vault, if used.
+// Without a passphrase the vault stays locked, and only the tokens kept elsewhere are read.
+func (c *Config) ResolveTokensCmd(passphrase string) tea.Cmd {
+	projects := slices.Clone(c.Projects)
+	usesVault := c.UsesTokenBackend(TokenBackendVault) && passphrase != ""
+	timeout := c.GetRequestTimeout()
+	return func() tea.Msg {
+		msg := TokensResolvedMsg{Tokens: make(map[string]string), Errors: make(map[string]error)}
+		if usesVault {
+			path, err := GetVaultPath()
+			if err == nil && !secret.VaultExists(path) {
+				err = fmt.Errorf("there is no token vault at %s yet, create it with 'lazyhetzner migrate-tokens vault'", path)
+			}
+			if err == nil {
+				msg.Vault, err = secret.OpenVault(path, passphrase)
+			}
+			if err != nil {
+				return TokensResolvedMsg{UnlockErr: err}
+			}
+		}
+
+		for _, project := range projects {
+			var backend secret.Backend
+			switch project.GetTokenBackend() {
+			case TokenBackendPlaintext:
+				continue
+			case TokenBackendVault:
+				if msg.Vault == nil {
+					msg.Errors[project.Name] = ErrVaultLocked
+					continue
+				}
+				backend = msg.Vault
+			case TokenBackendCommand:
+				backend = secret.Command{}
+			default:
+				msg.Errors[project.Name] = fmt.Errorf("unknown token backend %q", project.TokenBackend)
+				continue
+			}
+			if project.TokenRef == "" {
+				msg.Errors[project.Name] = errors.New("the project has no token_ref")
+				continue
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			token, err := backend.Get(ctx, project.TokenRef)
+			cancel()
+			if err != nil {
+				msg.Errors[project.Name] = err
+				continue
+			}
+			msg.Tokens[project.Name] = token
+		}
+		return msg
+	}
+}
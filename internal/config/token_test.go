@@ -0,0 +1,165 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/grammeaway/lazyhetzner/internal/secret"
+)
+
+func TestProjectConfigMarshalJSON(t *testing.T) {
+	tests := []struct {
+		name      string
+		project   ProjectConfig
+		wantToken bool
+	}{
+		{name: "legacy plaintext", project: ProjectConfig{Name: "p", Token: "secret-token"}, wantToken: true},
+		{name: "explicit plaintext", project: ProjectConfig{Name: "p", Token: "secret-token", TokenBackend: TokenBackendPlaintext}, wantToken: true},
+		{name: "migrated to the vault", project: ProjectConfig{Name: "p", Token: "secret-token", TokenBackend: TokenBackendVault, TokenRef: "p"}},
+		{name: "read from a command", project: ProjectConfig{Name: "p", Token: "secret-token", TokenBackend: TokenBackendCommand, TokenRef: "pass show p"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := json.Marshal(Config{Projects: []ProjectConfig{test.project}})
+			if err != nil {
+				t.Fatalf("marshalling: %v", err)
+			}
+			if got := strings.Contains(string(data), "secret-token"); got != test.wantToken {
+				t.Errorf("token written = %v, want %v: %s", got, test.wantToken, data)
+			}
+
+			var decoded Config
+			if err := json.Unmarshal(data, &decoded); err != nil {
+				t.Fatalf("unmarshalling: %v", err)
+			}
+			got := decoded.Projects[0]
+			if got.TokenBackend != test.project.TokenBackend || got.TokenRef != test.project.TokenRef {
+				t.Errorf("token source = %q %q, want %q %q", got.TokenBackend, got.TokenRef, test.project.TokenBackend, test.project.TokenRef)
+			}
+		})
+	}
+}
+
+// failingStore stores tokens in a vault, failing for a single project
+type failingStore struct {
+	*secret.Vault
+	failOn string
+}
+
+func (s failingStore) Set(ctx context.Context, project, token string) (string, error) {
+	if project == s.failOn {
+		return "", errors.New("store unavailable")
+	}
+	return s.Vault.Set(ctx, project, token)
+}
+
+// useTempConfigDir points the config directory of every platform to a temporary directory
+func useTempConfigDir(t *testing.T) string {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+	t.Setenv("AppData", dir)
+	configDir, err := GetConfigDir()
+	if err != nil {
+		t.Fatalf("creating the config dir: %v", err)
+	}
+	return configDir
+}
+
+func TestMigrateTokens(t *testing.T) {
+	projects := []ProjectConfig{
+		{Name: "production", Token: "token-production", ConfirmLevel: ConfirmLevelStrict},
+		{Name: "staging", Token: "token-staging"},
+		{Name: "dev", Token: "token-dev", ReadOnly: true},
+		{Name: "ops", TokenBackend: TokenBackendCommand, TokenRef: "pass show ops"},
+	}
+	tests := []struct {
+		name      string
+		failOn    string
+		wantMoved []string
+		wantErr   bool
+	}{
+		{name: "all moved", wantMoved: []string{"production", "staging", "dev"}},
+		{name: "failure halfway", failOn: "staging", wantMoved: []string{"production"}, wantErr: true},
+		{name: "failure on the first project", failOn: "production", wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			configDir := useTempConfigDir(t)
+			if err := saveConfig(&Config{Projects: projects, DefaultProject: "production"}); err != nil {
+				t.Fatalf("writing the config: %v", err)
+			}
+			vaultPath := filepath.Join(configDir, vaultFileName)
+			vault, err := secret.OpenVault(vaultPath, "secret")
+			if err != nil {
+				t.Fatalf("creating the vault: %v", err)
+			}
+
+			moved, err := MigrateTokens(context.Background(), TokenBackendVault, failingStore{Vault: vault, failOn: test.failOn})
+			if (err != nil) != test.wantErr {
+				t.Fatalf("error = %v, want an error: %v", err, test.wantErr)
+			}
+			if strings.Join(moved, ",") != strings.Join(test.wantMoved, ",") {
+				t.Errorf("moved = %v, want %v", moved, test.wantMoved)
+			}
+
+			config, err := loadConfig()
+			if err != nil {
+				t.Fatalf("reading the config back: %v", err)
+			}
+			if len(config.Projects) != len(projects) {
+				t.Fatalf("config has %d projects, want %d", len(config.Projects), len(projects))
+			}
+			var reopened *secret.Vault
+			if secret.VaultExists(vaultPath) {
+				if reopened, err = secret.OpenVault(vaultPath, "secret"); err != nil {
+					t.Fatalf("reopening the vault: %v", err)
+				}
+			}
+			for i, project := range config.Projects {
+				original := projects[i]
+				if project.ReadOnly != original.ReadOnly || project.ConfirmLevel != original.ConfirmLevel {
+					t.Errorf("%s lost its protection settings", project.Name)
+				}
+				switch project.GetTokenBackend() {
+				case TokenBackendPlaintext:
+					// Tokens that weren't moved stay usable from config.json
+					if project.Token != original.Token {
+						t.Errorf("plaintext token of %s = %q, want %q", project.Name, project.Token, original.Token)
+					}
+				case TokenBackendVault:
+					// Moved tokens are only in the vault
+					if project.Token != "" {
+						t.Errorf("the token of %s is still in config.json", project.Name)
+					}
+					if reopened == nil {
+						t.Fatalf("%s references the vault, but there is none", project.Name)
+					}
+					token, err := reopened.Get(context.Background(), project.TokenRef)
+					if err != nil || token != original.Token {
+						t.Errorf("vault token of %s = %q, %v, want %q", project.Name, token, err, original.Token)
+					}
+				case TokenBackendCommand:
+					if project.TokenRef != original.TokenRef {
+						t.Errorf("command of %s = %q, want it untouched", project.Name, project.TokenRef)
+					}
+				}
+			}
+
+			data, err := os.ReadFile(filepath.Join(configDir, "config.json"))
+			if err != nil {
+				t.Fatalf("reading config.json: %v", err)
+			}
+			for _, name := range test.wantMoved {
+				if strings.Contains(string(data), "token-"+name) {
+					t.Errorf("config.json still holds the token of %s", name)
+				}
+			}
+		})
+	}
+}
@@ -4,11 +4,14 @@ import (
 	"github.com/grammeaway/lazyhetzner/internal/resource"
 )
 
-
 // Context menu items
 type ContextMenuItem struct {
 	Label  string
 	Action string
+	// Mutating items change resources through the API, so read-only projects hide them
+	Mutating bool
+	// Destructive items disrupt or delete resources, so protected projects ask for confirmation first
+	Destructive bool
 }
 
 type ContextMenu struct {
@@ -16,4 +19,27 @@ type ContextMenu struct {
 	SelectedItem int
 	ResourceType resource.ResourceType
 	ResourceID   int64
+	// Project the resource belongs to in the all-projects view, empty for the current project
+	Project string
+}
+
+// WithoutMutating returns the items that don't change resources
+func WithoutMutating(items []ContextMenuItem) []ContextMenuItem {
+	kept := make([]ContextMenuItem, 0, len(items))
+	for _, item := range items {
+		if !item.Mutating {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
+
+// FindItem returns the item of an action
+func FindItem(items []ContextMenuItem, action string) (ContextMenuItem, bool) {
+	for _, item := range items {
+		if item.Action == action {
+			return item, true
+		}
+	}
+	return ContextMenuItem{}, false
 }
@@ -0,0 +1,408 @@
+package cost
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/grammeaway/lazyhetzner/internal/message"
+	"github.com/grammeaway/lazyhetzner/internal/registry"
+	"github.com/grammeaway/lazyhetzner/internal/resource"
+	"github.com/grammeaway/lazyhetzner/internal/resource/floatingip"
+	"github.com/grammeaway/lazyhetzner/internal/resource/loadbalancer"
+	"github.com/grammeaway/lazyhetzner/internal/resource/server"
+	"github.com/grammeaway/lazyhetzner/internal/resource/volume"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+// Resources that are only priced per month are spread over the average hours of a month
+const hoursPerMonth = 730
+
+// Categories of the line items, which is also the order they are listed in
+const (
+	CategoryServers       = "Servers"
+	CategoryBackups       = "Backups"
+	CategoryVolumes       = "Volumes"
+	CategoryFloatingIPs   = "Floating IPs"
+	CategoryPrimaryIPs    = "Primary IPs"
+	CategoryLoadBalancers = "Load Balancers"
+)
+
+// Amount is a price without and with VAT
+type Amount struct {
+	Net   float64
+	Gross float64
+}
+
+func (a Amount) add(other Amount) Amount {
+	return Amount{Net: a.Net + other.Net, Gross: a.Gross + other.Gross}
+}
+
+func (a Amount) scale(factor float64) Amount {
+	return Amount{Net: a.Net * factor, Gross: a.Gross * factor}
+}
+
+// Line is the estimated cost of a single resource. Priced is false if the pricing has no entry for it.
+type Line struct {
+	Category string
+	ID       int64
+	Name     string
+	Detail   string
+	Location string
+	Labels   map[string]string
+	Hourly   Amount
+	Monthly  Amount
+	Priced   bool
+}
+
+// Estimate holds the line items of a project's resources
+type Estimate struct {
+	Currency string
+	Lines    []Line
+}
+
+// Group is the summed cost of the line items sharing a key
+type Group struct {
+	Key     string
+	Count   int
+	Hourly  Amount
+	Monthly Amount
+}
+
+type EstimateLoadedMsg struct {
+	Estimate Estimate
+}
+
+// ExportedMsg reports where an estimate was exported to, or why exporting it failed
+type ExportedMsg struct {
+	Path string
+	Err  error
+}
+
+// Snapshot holds the loaded resources of a project that cost money
+type Snapshot struct {
+	Servers       []*hcloud.Server
+	Volumes       []*hcloud.Volume
+	FloatingIPs   []*hcloud.FloatingIP
+	LoadBalancers []*hcloud.LoadBalancer
+}
+
+// Collect takes the resources to estimate from the loaded tabs
+func Collect(related registry.Related) Snapshot {
+	var snapshot Snapshot
+	for _, item := range related(resource.ResourceServers) {
+		if serverItem, ok := item.(server.ServerItem); ok && serverItem.Server != nil {
+			snapshot.Servers = append(snapshot.Servers, serverItem.Server)
+		}
+	}
+	for _, item := range related(resource.ResourceVolumes) {
+		if volumeItem, ok := item.(volume.VolumeItem); ok && volumeItem.Volume != nil {
+			snapshot.Volumes = append(snapshot.Volumes, volumeItem.Volume)
+		}
+	}
+	for _, item := range related(resource.ResourceFloatingIPs) {
+		if floatingIPItem, ok := item.(floatingip.FloatingIPItem); ok && floatingIPItem.FloatingIP != nil {
+			snapshot.FloatingIPs = append(snapshot.FloatingIPs, floatingIPItem.FloatingIP)
+		}
+	}
+	for _, item := range related(resource.ResourceLoadBalancers) {
+		if lbItem, ok := item.(loadbalancer.LoadBalancerItem); ok && lbItem.Lb != nil {
+			snapshot.LoadBalancers = append(snapshot.LoadBalancers, lbItem.Lb)
+		}
+	}
+	return snapshot
+}
+
+// LoadEstimate fetches the current prices and the primary IPs matching the label selector, as they
+// have no tab of their own, and estimates the cost of the snapshot's resources
+func LoadEstimate(ctx context.Context, client *hcloud.Client, snapshot Snapshot, primaryIPSelector string) tea.Cmd {
+	return func() tea.Msg {
+		pricing, _, err := client.Pricing.Get(ctx)
+		if err != nil {
+			return message.ErrorMsg{Err: fmt.Errorf("loading prices: %w", err)}
+		}
+		primaryIPs, err := client.PrimaryIP.AllWithOpts(ctx, hcloud.PrimaryIPListOpts{
+			ListOpts: hcloud.ListOpts{LabelSelector: primaryIPSelector},
+		})
+		if err != nil {
+			return message.ErrorMsg{Err: fmt.Errorf("loading primary IPs: %w", err)}
+		}
+		return EstimateLoadedMsg{Estimate: Calculate(pricing, snapshot, primaryIPs)}
+	}
+}
+
+// Calculate prices every resource, including the backup surcharge of servers with backups enabled
+func Calculate(pricing hcloud.Pricing, snapshot Snapshot, primaryIPs []*hcloud.PrimaryIP) Estimate {
+	estimate := Estimate{Currency: getCurrency(pricing)}
+	backupShare := parseAmount(pricing.ServerBackup.Percentage) / 100
+
+	for _, s := range snapshot.Servers {
+		line := Line{Category: CategoryServers, ID: s.ID, Name: s.Name, Labels: s.Labels}
+		if s.Datacenter != nil && s.Datacenter.Location != nil {
+			line.Location = s.Datacenter.Location.Name
+		}
+		if s.ServerType != nil {
+			line.Detail = s.ServerType.Name
+			for _, typePricing := range pricing.ServerTypes {
+				if typePricing.ServerType == nil || typePricing.ServerType.Name != s.ServerType.Name {
+					continue
+				}
+				for _, locationPricing := range typePricing.Pricings {
+					if locationPricing.Location != nil && locationPricing.Location.Name == line.Location {
+						line.Hourly, line.Monthly, line.Priced = toAmount(locationPricing.Hourly), toAmount(locationPricing.Monthly), true
+					}
+				}
+			}
+		}
+		estimate.Lines = append(estimate.Lines, line)
+
+		if s.BackupWindow != "" {
+			backup := line
+			backup.Category = CategoryBackups
+			backup.Detail = fmt.Sprintf("%g%% of %s", backupShare*100, line.Detail)
+			backup.Hourly, backup.Monthly = line.Hourly.scale(backupShare), line.Monthly.scale(backupShare)
+			estimate.Lines = append(estimate.Lines, backup)
+		}
+	}
+
+	perGB := toAmount(pricing.Volume.PerGBMonthly)
+	for _, v := range snapshot.Volumes {
+		line := Line{Category: CategoryVolumes, ID: v.ID, Name: v.Name, Detail: fmt.Sprintf("%d GB", v.Size), Labels: v.Labels, Priced: true}
+		if v.Location != nil {
+			line.Location = v.Location.Name
+		}
+		line.Monthly = perGB.scale(float64(v.Size))
+		line.Hourly = line.Monthly.scale(1.0 / hoursPerMonth)
+		estimate.Lines = append(estimate.Lines, line)
+	}
+
+	for _, floatingIP := range snapshot.FloatingIPs {
+		line := Line{Category: CategoryFloatingIPs, ID: floatingIP.ID, Name: floatingIP.Name, Detail: string(floatingIP.Type), Labels: floatingIP.Labels}
+		if line.Name == "" {
+			line.Name = resource.FormatIP(floatingIP.IP)
+		}
+		if floatingIP.HomeLocation != nil {
+			line.Location = floatingIP.HomeLocation.Name
+		}
+		for _, typePricing := range pricing.FloatingIPs {
+			if typePricing.Type != floatingIP.Type {
+				continue
+			}
+			for _, locationPricing := range typePricing.Pricings {
+				if locationPricing.Location != nil && locationPricing.Location.Name == line.Location {
+					line.Monthly, line.Priced = toAmount(locationPricing.Monthly), true
+					line.Hourly = line.Monthly.scale(1.0 / hoursPerMonth)
+				}
+			}
+		}
+		estimate.Lines = append(estimate.Lines, line)
+	}
+
+	for _, primaryIP := range primaryIPs {
+		line := Line{Category: CategoryPrimaryIPs, ID: primaryIP.ID, Name: primaryIP.Name, Detail: string(primaryIP.Type), Labels: primaryIP.Labels}
+		if line.Name == "" {
+			line.Name = resource.FormatIP(primaryIP.IP)
+		}
+		if primaryIP.Datacenter != nil && primaryIP.Datacenter.Location != nil {
+			line.Location = primaryIP.Datacenter.Location.Name
+		}
+		for _, typePricing := range pricing.PrimaryIPs {
+			if typePricing.Type != string(primaryIP.Type) {
+				continue
+			}
+			for _, locationPricing := range typePricing.Pricings {
+				if locationPricing.Location == line.Location {
+					line.Hourly = Amount{Net: parseAmount(locationPricing.Hourly.Net), Gross: parseAmount(locationPricing.Hourly.Gross)}
+					line.Monthly = Amount{Net: parseAmount(locationPricing.Monthly.Net), Gross: parseAmount(locationPricing.Monthly.Gross)}
+					line.Priced = true
+				}
+			}
+		}
+		estimate.Lines = append(estimate.Lines, line)
+	}
+
+	for _, lb := range snapshot.LoadBalancers {
+		line := Line{Category: CategoryLoadBalancers, ID: lb.ID, Name: lb.Name, Labels: lb.Labels}
+		if lb.Location != nil {
+			line.Location = lb.Location.Name
+		}
+		if lb.LoadBalancerType != nil {
+			line.Detail = lb.LoadBalancerType.Name
+			for _, typePricing := range pricing.LoadBalancerTypes {
+				if typePricing.LoadBalancerType == nil || typePricing.LoadBalancerType.Name != lb.LoadBalancerType.Name {
+					continue
+				}
+				for _, locationPricing := range typePricing.Pricings {
+					if locationPricing.Location != nil && locationPricing.Location.Name == line.Location {
+						line.Hourly, line.Monthly, line.Priced = toAmount(locationPricing.Hourly), toAmount(locationPricing.Monthly), true
+					}
+				}
+			}
+		}
+		estimate.Lines = append(estimate.Lines, line)
+	}
+	return estimate
+}
+
+func getCurrency(pricing hcloud.Pricing) string {
+	if pricing.Volume.PerGBMonthly.Currency != "" {
+		return pricing.Volume.PerGBMonthly.Currency
+	}
+	return "EUR"
+}
+
+func toAmount(price hcloud.Price) Amount {
+	return Amount{Net: parseAmount(price.Net), Gross: parseAmount(price.Gross)}
+}
+
+// parseAmount parses the decimal strings of the pricing API, treating missing values as free
+func parseAmount(value string) float64 {
+	amount, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return 0
+	}
+	return amount
+}
+
+// Total sums up every line item
+func (e Estimate) Total() Group {
+	total := Group{Key: "Total"}
+	for _, line := range e.Lines {
+		total.Count++
+		total.Hourly = total.Hourly.add(line.Hourly)
+		total.Monthly = total.Monthly.add(line.Monthly)
+	}
+	return total
+}
+
+// Unpriced counts the line items the pricing had no price for
+func (e Estimate) Unpriced() int {
+	count := 0
+	for _, line := range e.Lines {
+		if !line.Priced {
+			count++
+		}
+	}
+	return count
+}
+
+// GroupBy sums up the line items by the key returned for each, most expensive group first
+func (e Estimate) GroupBy(key func(Line) string) []Group {
+	groups := map[string]*Group{}
+	for _, line := range e.Lines {
+		k := key(line)
+		group, exists := groups[k]
+		if !exists {
+			group = &Group{Key: k}
+			groups[k] = group
+		}
+		group.Count++
+		group.Hourly = group.Hourly.add(line.Hourly)
+		group.Monthly = group.Monthly.add(line.Monthly)
+	}
+	result := make([]Group, 0, len(groups))
+	for _, group := range groups {
+		result = append(result, *group)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Monthly.Gross != result[j].Monthly.Gross {
+			return result[i].Monthly.Gross > result[j].Monthly.Gross
+		}
+		return result[i].Key < result[j].Key
+	})
+	return result
+}
+
+// ByCategory keys line items by their resource type
+func ByCategory(line Line) string {
+	return line.Category
+}
+
+// ByLocation keys line items by their location
+func ByLocation(line Line) string {
+	if line.Location == "" {
+		return "unknown"
+	}
+	return line.Location
+}
+
+// ByLabel keys line items by the value of a label
+func ByLabel(labelKey string) func(Line) string {
+	return func(line Line) string {
+		if value, ok := line.Labels[labelKey]; ok {
+			return fmt.Sprintf("%s=%s", labelKey, value)
+		}
+		return fmt.Sprintf("no %s label", labelKey)
+	}
+}
+
+// LabelKeys returns the label keys used by any line item, in alphabetical order
+func (e Estimate) LabelKeys() []string {
+	seen := map[string]bool{}
+	for _, line := range e.Lines {
+		for labelKey := range line.Labels {
+			seen[labelKey] = true
+		}
+	}
+	labelKeys := make([]string, 0, len(seen))
+	for labelKey := range seen {
+		labelKeys = append(labelKeys, labelKey)
+	}
+	sort.Strings(labelKeys)
+	return labelKeys
+}
+
+// WriteCSV writes one row per line item, with net and gross amounts
+func WriteCSV(w io.Writer, e Estimate) error {
+	writer := csv.NewWriter(w)
+	header := []string{"category", "id", "name", "detail", "location", "labels", "priced", "currency", "hourly_net", "hourly_gross", "monthly_net", "monthly_gross"}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	for _, line := range e.Lines {
+		record := []string{
+			line.Category,
+			strconv.FormatInt(line.ID, 10),
+			line.Name,
+			line.Detail,
+			line.Location,
+			resource.FormatLabels(line.Labels),
+			strconv.FormatBool(line.Priced),
+			e.Currency,
+			formatCSVAmount(line.Hourly.Net),
+			formatCSVAmount(line.Hourly.Gross),
+			formatCSVAmount(line.Monthly.Net),
+			formatCSVAmount(line.Monthly.Gross),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func formatCSVAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 4, 64)
+}
+
+// ExportCSVCmd writes the estimate to a CSV file
+func ExportCSVCmd(e Estimate, path string) tea.Cmd {
+	return func() tea.Msg {
+		file, err := os.Create(path)
+		if err != nil {
+			return ExportedMsg{Path: path, Err: err}
+		}
+		if err := WriteCSV(file, e); err != nil {
+			file.Close()
+			return ExportedMsg{Path: path, Err: err}
+		}
+		return ExportedMsg{Path: path, Err: file.Close()}
+	}
+}
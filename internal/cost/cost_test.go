@@ -0,0 +1,188 @@
+package cost
+
+import (
+	"math"
+	"net"
+	"testing"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+var (
+	fsn1 = &hcloud.Location{Name: "fsn1"}
+	hel1 = &hcloud.Location{Name: "hel1"}
+)
+
+// testPricing is a fixed excerpt of the pricing API, with 19% VAT
+var testPricing = hcloud.Pricing{
+	ServerBackup: hcloud.ServerBackupPricing{Percentage: "20.0000000000"},
+	ServerTypes: []hcloud.ServerTypePricing{{
+		ServerType: &hcloud.ServerType{Name: "cx22"},
+		Pricings: []hcloud.ServerTypeLocationPricing{{
+			Location: fsn1,
+			Hourly:   hcloud.Price{Currency: "EUR", Net: "0.0060000000", Gross: "0.0071400000"},
+			Monthly:  hcloud.Price{Currency: "EUR", Net: "3.7900000000", Gross: "4.5101000000"},
+		}},
+	}},
+	Volume: hcloud.VolumePricing{PerGBMonthly: hcloud.Price{Currency: "EUR", Net: "0.0440000000", Gross: "0.0523600000"}},
+	FloatingIPs: []hcloud.FloatingIPTypePricing{{
+		Type: hcloud.FloatingIPTypeIPv4,
+		Pricings: []hcloud.FloatingIPTypeLocationPricing{{
+			Location: fsn1,
+			Monthly:  hcloud.Price{Currency: "EUR", Net: "3.0000000000", Gross: "3.5700000000"},
+		}},
+	}},
+	PrimaryIPs: []hcloud.PrimaryIPPricing{{
+		Type: "ipv4",
+		Pricings: []hcloud.PrimaryIPTypePricing{{
+			Location: "fsn1",
+			Hourly:   hcloud.PrimaryIPPrice{Net: "0.0008000000", Gross: "0.0009520000"},
+			Monthly:  hcloud.PrimaryIPPrice{Net: "0.5000000000", Gross: "0.5950000000"},
+		}},
+	}},
+	LoadBalancerTypes: []hcloud.LoadBalancerTypePricing{{
+		LoadBalancerType: &hcloud.LoadBalancerType{Name: "lb11"},
+		Pricings: []hcloud.LoadBalancerTypeLocationPricing{{
+			Location: fsn1,
+			Hourly:   hcloud.Price{Currency: "EUR", Net: "0.0088000000", Gross: "0.0104720000"},
+			Monthly:  hcloud.Price{Currency: "EUR", Net: "5.3900000000", Gross: "6.4141000000"},
+		}},
+	}},
+}
+
+func newTestServer(name string, location *hcloud.Location, serverType string, backupWindow string) *hcloud.Server {
+	return &hcloud.Server{
+		Name:         name,
+		Datacenter:   &hcloud.Datacenter{Location: location},
+		ServerType:   &hcloud.ServerType{Name: serverType},
+		BackupWindow: backupWindow,
+	}
+}
+
+func TestCalculate(t *testing.T) {
+	tests := []struct {
+		name       string
+		snapshot   Snapshot
+		primaryIPs []*hcloud.PrimaryIP
+		want       []Line
+	}{
+		{
+			name:     "server",
+			snapshot: Snapshot{Servers: []*hcloud.Server{newTestServer("web", fsn1, "cx22", "")}},
+			want: []Line{
+				{Category: CategoryServers, Name: "web", Detail: "cx22", Location: "fsn1", Hourly: Amount{0.006, 0.00714}, Monthly: Amount{3.79, 4.5101}, Priced: true},
+			},
+		},
+		{
+			name:     "server with backups",
+			snapshot: Snapshot{Servers: []*hcloud.Server{newTestServer("web", fsn1, "cx22", "22-02")}},
+			want: []Line{
+				{Category: CategoryServers, Name: "web", Detail: "cx22", Location: "fsn1", Hourly: Amount{0.006, 0.00714}, Monthly: Amount{3.79, 4.5101}, Priced: true},
+				{Category: CategoryBackups, Name: "web", Detail: "20% of cx22", Location: "fsn1", Hourly: Amount{0.0012, 0.001428}, Monthly: Amount{0.758, 0.90202}, Priced: true},
+			},
+		},
+		{
+			name:     "server type without a price in its location",
+			snapshot: Snapshot{Servers: []*hcloud.Server{newTestServer("web", hel1, "cx22", "22-02")}},
+			want: []Line{
+				{Category: CategoryServers, Name: "web", Detail: "cx22", Location: "hel1"},
+				{Category: CategoryBackups, Name: "web", Detail: "20% of cx22", Location: "hel1"},
+			},
+		},
+		{
+			name:     "unknown server type",
+			snapshot: Snapshot{Servers: []*hcloud.Server{newTestServer("web", fsn1, "cx99", "")}},
+			want: []Line{
+				{Category: CategoryServers, Name: "web", Detail: "cx99", Location: "fsn1"},
+			},
+		},
+		{
+			name:     "volume priced per GB and month",
+			snapshot: Snapshot{Volumes: []*hcloud.Volume{{Name: "data", Size: 100, Location: fsn1}}},
+			want: []Line{
+				{Category: CategoryVolumes, Name: "data", Detail: "100 GB", Location: "fsn1", Hourly: Amount{4.4 / 730, 5.236 / 730}, Monthly: Amount{4.4, 5.236}, Priced: true},
+			},
+		},
+		{
+			name: "floating IPs priced per month",
+			snapshot: Snapshot{FloatingIPs: []*hcloud.FloatingIP{
+				{Type: hcloud.FloatingIPTypeIPv4, IP: net.ParseIP("198.51.100.7"), HomeLocation: fsn1},
+				{Name: "fip-v6", Type: hcloud.FloatingIPTypeIPv6, IP: net.ParseIP("2a01:4f8:1:2::"), HomeLocation: fsn1},
+			}},
+			want: []Line{
+				{Category: CategoryFloatingIPs, Name: "198.51.100.7", Detail: "ipv4", Location: "fsn1", Hourly: Amount{3.0 / 730, 3.57 / 730}, Monthly: Amount{3.0, 3.57}, Priced: true},
+				{Category: CategoryFloatingIPs, Name: "fip-v6", Detail: "ipv6", Location: "fsn1"},
+			},
+		},
+		{
+			name: "primary IPs",
+			primaryIPs: []*hcloud.PrimaryIP{
+				{Name: "web-v4", Type: hcloud.PrimaryIPTypeIPv4, Datacenter: &hcloud.Datacenter{Location: fsn1}},
+				{Name: "web-v6", Type: hcloud.PrimaryIPTypeIPv6, Datacenter: &hcloud.Datacenter{Location: fsn1}},
+				{Name: "spare", Type: hcloud.PrimaryIPTypeIPv4, Datacenter: &hcloud.Datacenter{Location: hel1}},
+			},
+			want: []Line{
+				{Category: CategoryPrimaryIPs, Name: "web-v4", Detail: "ipv4", Location: "fsn1", Hourly: Amount{0.0008, 0.000952}, Monthly: Amount{0.5, 0.595}, Priced: true},
+				{Category: CategoryPrimaryIPs, Name: "web-v6", Detail: "ipv6", Location: "fsn1"},
+				{Category: CategoryPrimaryIPs, Name: "spare", Detail: "ipv4", Location: "hel1"},
+			},
+		},
+		{
+			name: "load balancers",
+			snapshot: Snapshot{LoadBalancers: []*hcloud.LoadBalancer{
+				{Name: "lb", LoadBalancerType: &hcloud.LoadBalancerType{Name: "lb11"}, Location: fsn1},
+				{Name: "lb-big", LoadBalancerType: &hcloud.LoadBalancerType{Name: "lb31"}, Location: fsn1},
+			}},
+			want: []Line{
+				{Category: CategoryLoadBalancers, Name: "lb", Detail: "lb11", Location: "fsn1", Hourly: Amount{0.0088, 0.010472}, Monthly: Amount{5.39, 6.4141}, Priced: true},
+				{Category: CategoryLoadBalancers, Name: "lb-big", Detail: "lb31", Location: "fsn1"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			estimate := Calculate(testPricing, test.snapshot, test.primaryIPs)
+			if estimate.Currency != "EUR" {
+				t.Errorf("currency = %q, want EUR", estimate.Currency)
+			}
+			if len(estimate.Lines) != len(test.want) {
+				t.Fatalf("got %d lines, want %d: %+v", len(estimate.Lines), len(test.want), estimate.Lines)
+			}
+			for i, want := range test.want {
+				got := estimate.Lines[i]
+				if got.Category != want.Category || got.Name != want.Name || got.Detail != want.Detail || got.Location != want.Location || got.Priced != want.Priced {
+					t.Errorf("line %d = %s %q (%s, %s, priced %v), want %s %q (%s, %s, priced %v)", i,
+						got.Category, got.Name, got.Detail, got.Location, got.Priced,
+						want.Category, want.Name, want.Detail, want.Location, want.Priced)
+				}
+				if !equalAmounts(got.Hourly, want.Hourly) || !equalAmounts(got.Monthly, want.Monthly) {
+					t.Errorf("line %d %s costs %v/h and %v/mo, want %v/h and %v/mo", i, got.Name, got.Hourly, got.Monthly, want.Hourly, want.Monthly)
+				}
+			}
+		})
+	}
+}
+
+func TestCalculateTotal(t *testing.T) {
+	snapshot := Snapshot{
+		Servers: []*hcloud.Server{newTestServer("web", fsn1, "cx22", "22-02"), newTestServer("web-hel", hel1, "cx22", "")},
+		Volumes: []*hcloud.Volume{{Name: "data", Size: 10, Location: fsn1}},
+	}
+	estimate := Calculate(testPricing, snapshot, nil)
+
+	total := estimate.Total()
+	if total.Count != 4 {
+		t.Errorf("total count = %d, want 4", total.Count)
+	}
+	if want := (Amount{3.79 + 0.758 + 0.44, 4.5101 + 0.90202 + 0.5236}); !equalAmounts(total.Monthly, want) {
+		t.Errorf("monthly total = %v, want %v", total.Monthly, want)
+	}
+	if unpriced := estimate.Unpriced(); unpriced != 1 {
+		t.Errorf("unpriced lines = %d, want 1", unpriced)
+	}
+}
+
+func equalAmounts(a, b Amount) bool {
+	const epsilon = 1e-9
+	return math.Abs(a.Net-b.Net) < epsilon && math.Abs(a.Gross-b.Gross) < epsilon
+}
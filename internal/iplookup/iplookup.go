@@ -0,0 +1,294 @@
+package iplookup
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"sort"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/grammeaway/lazyhetzner/internal/message"
+	"github.com/grammeaway/lazyhetzner/internal/resource"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+// Owner describes a resource that owns, or contains, the looked up address.
+// ResourceType and ResourceID point at the tab item to jump to; ResourceID is 0 if there is none.
+// Project is only set for lookups across projects.
+type Owner struct {
+	Kind         string
+	ID           int64
+	Name         string
+	Address      string
+	Detail       string
+	ResourceType resource.ResourceType
+	ResourceID   int64
+	Project      string
+}
+
+// Snapshot holds everything an address can be attached to within a project
+type Snapshot struct {
+	Servers       []*hcloud.Server
+	FloatingIPs   []*hcloud.FloatingIP
+	PrimaryIPs    []*hcloud.PrimaryIP
+	LoadBalancers []*hcloud.LoadBalancer
+	Networks      []*hcloud.Network
+}
+
+type IPLookupResultMsg struct {
+	Query  string
+	Owners []Owner
+	// Errors holds the projects that could not be searched in a lookup across projects
+	Errors map[string]error
+}
+
+// ParseQuery parses an IPv4/IPv6 address or CIDR into a prefix; single addresses become host prefixes
+func ParseQuery(query string) (netip.Prefix, error) {
+	query = strings.TrimSpace(query)
+	if strings.Contains(query, "/") {
+		prefix, err := netip.ParsePrefix(query)
+		if err != nil {
+			return netip.Prefix{}, fmt.Errorf("invalid CIDR %q", query)
+		}
+		return prefix.Masked(), nil
+	}
+	addr, err := netip.ParseAddr(query)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid IP address %q", query)
+	}
+	addr = addr.Unmap()
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+// LoadSnapshot fetches every resource type an address can belong to
+func LoadSnapshot(ctx context.Context, client *hcloud.Client) (Snapshot, error) {
+	var (
+		snapshot Snapshot
+		err      error
+	)
+	if snapshot.Servers, err = client.Server.All(ctx); err != nil {
+		return snapshot, err
+	}
+	if snapshot.FloatingIPs, err = client.FloatingIP.All(ctx); err != nil {
+		return snapshot, err
+	}
+	if snapshot.PrimaryIPs, err = client.PrimaryIP.All(ctx); err != nil {
+		return snapshot, err
+	}
+	if snapshot.LoadBalancers, err = client.LoadBalancer.All(ctx); err != nil {
+		return snapshot, err
+	}
+	if snapshot.Networks, err = client.Network.All(ctx); err != nil {
+		return snapshot, err
+	}
+	return snapshot, nil
+}
+
+func LookupIP(ctx context.Context, client *hcloud.Client, query string) tea.Cmd {
+	return func() tea.Msg {
+		prefix, err := ParseQuery(query)
+		if err != nil {
+			return message.ErrorMsg{Err: err}
+		}
+		snapshot, err := LoadSnapshot(ctx, client)
+		if err != nil {
+			return message.ErrorMsg{Err: err}
+		}
+		return IPLookupResultMsg{Query: prefix.String(), Owners: FindOwners(prefix, snapshot)}
+	}
+}
+
+// LookupIPInProjects looks up the address in every project at the same time, tagging owners with their project
+func LookupIPInProjects(ctx context.Context, clients map[string]*hcloud.Client, query string) tea.Cmd {
+	return func() tea.Msg {
+		prefix, err := ParseQuery(query)
+		if err != nil {
+			return message.ErrorMsg{Err: err}
+		}
+
+		projects := make([]string, 0, len(clients))
+		for project := range clients {
+			projects = append(projects, project)
+		}
+		sort.Strings(projects)
+
+		snapshots := make([]Snapshot, len(projects))
+		errs := make([]error, len(projects))
+		var wg sync.WaitGroup
+		for i, project := range projects {
+			wg.Add(1)
+			go func(i int, client *hcloud.Client) {
+				defer wg.Done()
+				snapshots[i], errs[i] = LoadSnapshot(ctx, client)
+			}(i, clients[project])
+		}
+		wg.Wait()
+
+		result := IPLookupResultMsg{Query: prefix.String(), Owners: []Owner{}, Errors: make(map[string]error)}
+		for i, project := range projects {
+			if errs[i] != nil {
+				result.Errors[project] = errs[i]
+				continue
+			}
+			for _, owner := range FindOwners(prefix, snapshots[i]) {
+				owner.Project = project
+				result.Owners = append(result.Owners, owner)
+			}
+		}
+		return result
+	}
+}
+
+// FindOwners returns every resource in the snapshot whose address or range overlaps the query prefix
+func FindOwners(query netip.Prefix, snapshot Snapshot) []Owner {
+	owners := []Owner{}
+	add := func(owner Owner, candidate netip.Prefix, ok bool) {
+		if ok && candidate.Overlaps(query) {
+			owner.Address = candidate.String()
+			if candidate.IsSingleIP() {
+				owner.Address = candidate.Addr().String()
+			}
+			owners = append(owners, owner)
+		}
+	}
+
+	networkNames := make(map[int64]string, len(snapshot.Networks))
+	for _, network := range snapshot.Networks {
+		networkNames[network.ID] = network.Name
+	}
+
+	serverNames := make(map[int64]string, len(snapshot.Servers))
+	for _, server := range snapshot.Servers {
+		serverNames[server.ID] = server.Name
+		owner := Owner{Kind: "Server", ID: server.ID, Name: server.Name, ResourceType: resource.ResourceServers, ResourceID: server.ID}
+
+		owner.Detail = "Public IPv4"
+		candidate, ok := hostPrefix(server.PublicNet.IPv4.IP)
+		add(owner, candidate, ok)
+		owner.Detail = "Public IPv6 block"
+		candidate, ok = networkPrefix(server.PublicNet.IPv6.Network)
+		add(owner, candidate, ok)
+
+		for _, privateNet := range server.PrivateNet {
+			networkName := "unknown network"
+			if privateNet.Network != nil {
+				networkName = fmt.Sprintf("network %d", privateNet.Network.ID)
+				if name := networkNames[privateNet.Network.ID]; name != "" {
+					networkName = name
+				}
+			}
+			owner.Detail = "Private IP in " + networkName
+			candidate, ok = hostPrefix(privateNet.IP)
+			add(owner, candidate, ok)
+			owner.Detail = "Alias IP in " + networkName
+			for _, alias := range privateNet.Aliases {
+				candidate, ok = hostPrefix(alias)
+				add(owner, candidate, ok)
+			}
+		}
+	}
+
+	for _, floatingIP := range snapshot.FloatingIPs {
+		name := floatingIP.Name
+		if name == "" {
+			name = floatingIP.IP.String()
+		}
+		owner := Owner{Kind: "Floating IP", ID: floatingIP.ID, Name: name, Detail: "Unassigned", ResourceType: resource.ResourceFloatingIPs, ResourceID: floatingIP.ID}
+		if floatingIP.Server != nil {
+			owner.Detail = "Assigned to server " + getServerName(serverNames, floatingIP.Server.ID)
+		}
+		candidate, ok := networkPrefix(floatingIP.Network)
+		if !ok {
+			candidate, ok = hostPrefix(floatingIP.IP)
+		}
+		add(owner, candidate, ok)
+	}
+
+	for _, primaryIP := range snapshot.PrimaryIPs {
+		// Primary IPs have no tab of their own, so they link to the server they are assigned to
+		owner := Owner{Kind: "Primary IP", ID: primaryIP.ID, Name: primaryIP.Name, Detail: "Unassigned", ResourceType: resource.ResourceServers}
+		if primaryIP.AssigneeID != 0 && primaryIP.AssigneeType == "server" {
+			owner.Detail = "Assigned to server " + getServerName(serverNames, primaryIP.AssigneeID)
+			owner.ResourceID = primaryIP.AssigneeID
+		}
+		candidate, ok := networkPrefix(primaryIP.Network)
+		if !ok {
+			candidate, ok = hostPrefix(primaryIP.IP)
+		}
+		add(owner, candidate, ok)
+	}
+
+	for _, lb := range snapshot.LoadBalancers {
+		owner := Owner{Kind: "Load Balancer", ID: lb.ID, Name: lb.Name, ResourceType: resource.ResourceLoadBalancers, ResourceID: lb.ID}
+		if lb.PublicNet.Enabled {
+			owner.Detail = "Public IPv4"
+			candidate, ok := hostPrefix(lb.PublicNet.IPv4.IP)
+			add(owner, candidate, ok)
+			owner.Detail = "Public IPv6"
+			candidate, ok = hostPrefix(lb.PublicNet.IPv6.IP)
+			add(owner, candidate, ok)
+		}
+		for _, privateNet := range lb.PrivateNet {
+			owner.Detail = "Private IP"
+			if privateNet.Network != nil && networkNames[privateNet.Network.ID] != "" {
+				owner.Detail = "Private IP in " + networkNames[privateNet.Network.ID]
+			}
+			candidate, ok := hostPrefix(privateNet.IP)
+			add(owner, candidate, ok)
+		}
+	}
+
+	for _, network := range snapshot.Networks {
+		owner := Owner{Kind: "Network", ID: network.ID, Name: network.Name, ResourceType: resource.ResourceNetworks, ResourceID: network.ID}
+		for _, subnet := range network.Subnets {
+			owner.Kind = "Subnet"
+			owner.Detail = fmt.Sprintf("%s subnet in %s", strings.ToUpper(string(subnet.Type)), subnet.NetworkZone)
+			candidate, ok := networkPrefix(subnet.IPRange)
+			add(owner, candidate, ok)
+		}
+		owner.Kind = "Network"
+		owner.Detail = "Network IP range"
+		candidate, ok := networkPrefix(network.IPRange)
+		add(owner, candidate, ok)
+	}
+
+	return owners
+}
+
+func getServerName(serverNames map[int64]string, id int64) string {
+	if name, ok := serverNames[id]; ok && name != "" {
+		return name
+	}
+	return fmt.Sprintf("%d", id)
+}
+
+func hostPrefix(ip net.IP) (netip.Prefix, bool) {
+	if len(ip) == 0 {
+		return netip.Prefix{}, false
+	}
+	addr, ok := netip.AddrFromSlice(ip)
+	if !ok {
+		return netip.Prefix{}, false
+	}
+	addr = addr.Unmap()
+	return netip.PrefixFrom(addr, addr.BitLen()), true
+}
+
+func networkPrefix(ipNet *net.IPNet) (netip.Prefix, bool) {
+	if ipNet == nil || len(ipNet.IP) == 0 {
+		return netip.Prefix{}, false
+	}
+	addr, ok := netip.AddrFromSlice(ipNet.IP)
+	if !ok {
+		return netip.Prefix{}, false
+	}
+	addr = addr.Unmap()
+	ones, _ := ipNet.Mask.Size()
+	if addr.Is4() && ones > 32 {
+		ones -= 96
+	}
+	return netip.PrefixFrom(addr, ones).Masked(), true
+}
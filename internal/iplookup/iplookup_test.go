@@ -0,0 +1,231 @@
+package iplookup
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+func mustParseCIDR(t *testing.T, cidr string) *net.IPNet {
+	t.Helper()
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		t.Fatalf("parsing %s: %v", cidr, err)
+	}
+	return ipNet
+}
+
+func TestParseQuery(t *testing.T) {
+	tests := []struct {
+		query   string
+		want    string
+		wantErr bool
+	}{
+		{query: "203.0.113.10", want: "203.0.113.10/32"},
+		{query: "  203.0.113.10 ", want: "203.0.113.10/32"},
+		{query: "2a01:4f8:c0c:1234::1", want: "2a01:4f8:c0c:1234::1/128"},
+		{query: "::ffff:203.0.113.10", want: "203.0.113.10/32"},
+		{query: "10.0.1.7/16", want: "10.0.0.0/16"},
+		{query: "2a01:4f8:c0c:1234::1/64", want: "2a01:4f8:c0c:1234::/64"},
+		{query: "server-1", wantErr: true},
+		{query: "203.0.113.300", wantErr: true},
+		{query: "10.0.0.0/33", wantErr: true},
+		{query: "", wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			prefix, err := ParseQuery(test.query)
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("parsed %q as %s, want an error", test.query, prefix)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parsing %q: %v", test.query, err)
+			}
+			if prefix.String() != test.want {
+				t.Errorf("prefix = %s, want %s", prefix, test.want)
+			}
+		})
+	}
+}
+
+// getTestSnapshot returns a project with a server and everything attached to it
+func getTestSnapshot(t *testing.T) Snapshot {
+	network := &hcloud.Network{
+		ID:      10,
+		Name:    "backend",
+		IPRange: mustParseCIDR(t, "10.0.0.0/16"),
+		Subnets: []hcloud.NetworkSubnet{{
+			Type:        hcloud.NetworkSubnetTypeCloud,
+			IPRange:     mustParseCIDR(t, "10.0.1.0/24"),
+			NetworkZone: hcloud.NetworkZoneEUCentral,
+		}},
+	}
+	server := &hcloud.Server{
+		ID:   1,
+		Name: "web",
+		PublicNet: hcloud.ServerPublicNet{
+			IPv4: hcloud.ServerPublicNetIPv4{IP: net.ParseIP("203.0.113.10")},
+			IPv6: hcloud.ServerPublicNetIPv6{Network: mustParseCIDR(t, "2a01:4f8:c0c:1234::/64")},
+		},
+		PrivateNet: []hcloud.ServerPrivateNet{{
+			Network: &hcloud.Network{ID: network.ID},
+			IP:      net.ParseIP("10.0.1.5"),
+			Aliases: []net.IP{net.ParseIP("10.0.1.6")},
+		}},
+	}
+	return Snapshot{
+		Servers: []*hcloud.Server{server},
+		FloatingIPs: []*hcloud.FloatingIP{
+			{ID: 2, IP: net.ParseIP("198.51.100.7"), Server: &hcloud.Server{ID: server.ID}},
+			{ID: 3, Name: "fip-v6", IP: net.ParseIP("2a01:4f8:1:2::"), Network: mustParseCIDR(t, "2a01:4f8:1:2::/64")},
+		},
+		PrimaryIPs: []*hcloud.PrimaryIP{
+			{ID: 4, Name: "web-v4", IP: net.ParseIP("203.0.113.10"), AssigneeID: server.ID, AssigneeType: "server"},
+			// An IPv4 address in its 16 byte, IPv4-mapped form
+			{ID: 5, Name: "spare", IP: net.IP{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0xff, 0xff, 203, 0, 113, 20}},
+		},
+		LoadBalancers: []*hcloud.LoadBalancer{{
+			ID:   6,
+			Name: "lb",
+			PublicNet: hcloud.LoadBalancerPublicNet{
+				Enabled: true,
+				IPv4:    hcloud.LoadBalancerPublicNetIPv4{IP: net.ParseIP("203.0.113.30")},
+				IPv6:    hcloud.LoadBalancerPublicNetIPv6{IP: net.ParseIP("2a01:4f8:c0c:9999::1")},
+			},
+			PrivateNet: []hcloud.LoadBalancerPrivateNet{{
+				Network: &hcloud.Network{ID: network.ID},
+				IP:      net.ParseIP("10.0.1.10"),
+			}},
+		}},
+		Networks: []*hcloud.Network{network},
+	}
+}
+
+func TestFindOwners(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{
+			name:  "exact IPv4 address",
+			query: "203.0.113.10",
+			want: []string{
+				"Server 1 → 1: 203.0.113.10 (Public IPv4)",
+				"Primary IP 4 → 1: 203.0.113.10 (Assigned to server web)",
+			},
+		},
+		{
+			name:  "IPv4-mapped query",
+			query: "::ffff:203.0.113.10",
+			want: []string{
+				"Server 1 → 1: 203.0.113.10 (Public IPv4)",
+				"Primary IP 4 → 1: 203.0.113.10 (Assigned to server web)",
+			},
+		},
+		{
+			name:  "IPv4-mapped resource address",
+			query: "203.0.113.20",
+			want:  []string{"Primary IP 5 → 0: 203.0.113.20 (Unassigned)"},
+		},
+		{
+			name:  "address in a server's IPv6 /64",
+			query: "2a01:4f8:c0c:1234::1",
+			want:  []string{"Server 1 → 1: 2a01:4f8:c0c:1234::/64 (Public IPv6 block)"},
+		},
+		{
+			name:  "server's IPv6 /64 as CIDR",
+			query: "2a01:4f8:c0c:1234::/64",
+			want:  []string{"Server 1 → 1: 2a01:4f8:c0c:1234::/64 (Public IPv6 block)"},
+		},
+		{
+			name:  "address in an IPv6 floating IP",
+			query: "2a01:4f8:1:2::abcd",
+			want:  []string{"Floating IP 3 → 3: 2a01:4f8:1:2::/64 (Unassigned)"},
+		},
+		{
+			name:  "floating IP without a name",
+			query: "198.51.100.7",
+			want:  []string{"Floating IP 2 → 2: 198.51.100.7 (Assigned to server web)"},
+		},
+		{
+			name:  "load balancer IPv6",
+			query: "2a01:4f8:c0c:9999::1",
+			want:  []string{"Load Balancer 6 → 6: 2a01:4f8:c0c:9999::1 (Public IPv6)"},
+		},
+		{
+			name:  "alias IP within a subnet",
+			query: "10.0.1.6",
+			want: []string{
+				"Server 1 → 1: 10.0.1.6 (Alias IP in backend)",
+				"Subnet 10 → 10: 10.0.1.0/24 (CLOUD subnet in eu-central)",
+				"Network 10 → 10: 10.0.0.0/16 (Network IP range)",
+			},
+		},
+		{
+			name:  "subnet CIDR",
+			query: "10.0.1.0/24",
+			want: []string{
+				"Server 1 → 1: 10.0.1.5 (Private IP in backend)",
+				"Server 1 → 1: 10.0.1.6 (Alias IP in backend)",
+				"Load Balancer 6 → 6: 10.0.1.10 (Private IP in backend)",
+				"Subnet 10 → 10: 10.0.1.0/24 (CLOUD subnet in eu-central)",
+				"Network 10 → 10: 10.0.0.0/16 (Network IP range)",
+			},
+		},
+		{
+			name:  "wider CIDR covering the network",
+			query: "10.0.0.0/8",
+			want: []string{
+				"Server 1 → 1: 10.0.1.5 (Private IP in backend)",
+				"Server 1 → 1: 10.0.1.6 (Alias IP in backend)",
+				"Load Balancer 6 → 6: 10.0.1.10 (Private IP in backend)",
+				"Subnet 10 → 10: 10.0.1.0/24 (CLOUD subnet in eu-central)",
+				"Network 10 → 10: 10.0.0.0/16 (Network IP range)",
+			},
+		},
+		{
+			name:  "address in the network outside the subnet",
+			query: "10.0.2.1",
+			want:  []string{"Network 10 → 10: 10.0.0.0/16 (Network IP range)"},
+		},
+		{name: "unknown IPv4 address", query: "192.0.2.1"},
+		{name: "unknown IPv6 network", query: "2001:db8::/32"},
+		{name: "neighbouring IPv6 /64", query: "2a01:4f8:c0c:1235::1"},
+	}
+	snapshot := getTestSnapshot(t)
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			query, err := ParseQuery(test.query)
+			if err != nil {
+				t.Fatalf("parsing %q: %v", test.query, err)
+			}
+			got := []string{}
+			for _, owner := range FindOwners(query, snapshot) {
+				got = append(got, fmt.Sprintf("%s %d → %d: %s (%s)", owner.Kind, owner.ID, owner.ResourceID, owner.Address, owner.Detail))
+			}
+			if strings.Join(got, "\n") != strings.Join(test.want, "\n") {
+				t.Errorf("owners of %s:\n%s\nwant:\n%s", test.query, strings.Join(got, "\n"), strings.Join(test.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestNetworkPrefixIPv4Mapped(t *testing.T) {
+	// IPv4 networks may come with a 16 byte address and a 128 bit mask
+	ipNet := &net.IPNet{IP: net.ParseIP("10.0.0.0"), Mask: net.CIDRMask(112, 128)}
+	prefix, ok := networkPrefix(ipNet)
+	if !ok {
+		t.Fatal("the network wasn't converted")
+	}
+	if want := netip.MustParsePrefix("10.0.0.0/16"); prefix != want {
+		t.Errorf("prefix = %s, want %s", prefix, want)
+	}
+}
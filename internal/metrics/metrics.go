@@ -0,0 +1,160 @@
+package metrics
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grammeaway/lazyhetzner/internal/resource"
+)
+
+// Points is roughly how many values are requested per series, whatever the window
+const Points = 120
+
+// Window is a time range to show metrics for, ending now
+type Window struct {
+	Name     string
+	Duration time.Duration
+}
+
+// Windows are the selectable time ranges, shortest first
+var Windows = []Window{
+	{Name: "1h", Duration: time.Hour},
+	{Name: "24h", Duration: 24 * time.Hour},
+	{Name: "7d", Duration: 7 * 24 * time.Hour},
+}
+
+// Step returns the seconds between two values of the window
+func (w Window) Step() int {
+	return max(1, int(w.Duration.Seconds())/Points)
+}
+
+// Range returns the start and end of the window, ending now
+func (w Window) Range() (time.Time, time.Time) {
+	end := time.Now()
+	return end.Add(-w.Duration), end
+}
+
+// Unit tells how the values of a series are formatted
+type Unit int
+
+const (
+	UnitNone Unit = iota
+	UnitPercent
+	UnitBytesPerSecond
+	UnitPerSecond
+)
+
+// Series is a single metric over time
+type Series struct {
+	Title  string
+	Unit   Unit
+	Values []float64
+}
+
+// LoadedMsg holds the metrics of a resource over a window. Err is set if they could not be loaded.
+type LoadedMsg struct {
+	ResourceType resource.ResourceType
+	ID           int64
+	Window       Window
+	Series       []Series
+	Err          error
+}
+
+// ParseValue parses a value of the metrics API, which sends numbers as strings
+func ParseValue(value string) float64 {
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsNaN(parsed) {
+		return 0
+	}
+	return parsed
+}
+
+// Summary holds the lowest, average, highest and latest value of a series
+type Summary struct {
+	Min, Avg, Max, Last float64
+}
+
+func Summarize(values []float64) Summary {
+	if len(values) == 0 {
+		return Summary{}
+	}
+	summary := Summary{Min: values[0], Max: values[0], Last: values[len(values)-1]}
+	sum := 0.0
+	for _, value := range values {
+		summary.Min = min(summary.Min, value)
+		summary.Max = max(summary.Max, value)
+		sum += value
+	}
+	summary.Avg = sum / float64(len(values))
+	return summary
+}
+
+// Blocks of increasing height that make up a sparkline
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline draws the values as a single line of blocks, averaging neighbouring values to fit the width.
+// Blocks are scaled from zero to the highest value, so flat series at zero stay at the bottom.
+func Sparkline(values []float64, width int) string {
+	if len(values) == 0 || width <= 0 {
+		return ""
+	}
+	buckets := min(width, len(values))
+	averaged := make([]float64, buckets)
+	highest := 0.0
+	for i := range buckets {
+		from, to := i*len(values)/buckets, (i+1)*len(values)/buckets
+		sum := 0.0
+		for _, value := range values[from:to] {
+			sum += value
+		}
+		averaged[i] = sum / float64(to-from)
+		highest = max(highest, averaged[i])
+	}
+	var line strings.Builder
+	for _, value := range averaged {
+		level := 0
+		if highest > 0 {
+			level = int(value / highest * float64(len(sparkBlocks)-1))
+		}
+		line.WriteRune(sparkBlocks[max(0, min(len(sparkBlocks)-1, level))])
+	}
+	return line.String()
+}
+
+// Format formats a value in the unit, scaling bytes to readable sizes
+func (u Unit) Format(value float64) string {
+	switch u {
+	case UnitPercent:
+		return fmt.Sprintf("%.1f%%", value)
+	case UnitBytesPerSecond:
+		return formatBytes(value) + "/s"
+	case UnitPerSecond:
+		return formatNumber(value) + "/s"
+	default:
+		return formatNumber(value)
+	}
+}
+
+func formatBytes(value float64) string {
+	units := []string{"B", "KB", "MB", "GB", "TB"}
+	unit := 0
+	for value >= 1024 && unit < len(units)-1 {
+		value /= 1024
+		unit++
+	}
+	return fmt.Sprintf("%.1f %s", value, units[unit])
+}
+
+func formatNumber(value float64) string {
+	switch {
+	case value >= 1_000_000:
+		return fmt.Sprintf("%.1fM", value/1_000_000)
+	case value >= 1_000:
+		return fmt.Sprintf("%.1fk", value/1_000)
+	default:
+		return fmt.Sprintf("%.1f", value)
+	}
+}
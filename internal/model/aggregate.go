@@ -0,0 +1,218 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/grammeaway/lazyhetzner/internal/message"
+	"github.com/grammeaway/lazyhetzner/internal/resource"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+// projectResourceItem wraps a resource item loaded in the all-projects view with the project it belongs to
+type projectResourceItem struct {
+	list.DefaultItem
+	Project string
+}
+
+func (i projectResourceItem) FilterValue() string {
+	return i.Project + " " + i.DefaultItem.FilterValue()
+}
+func (i projectResourceItem) Title() string {
+	return fmt.Sprintf("[%s] %s", i.Project, i.DefaultItem.Title())
+}
+func (i projectResourceItem) SearchTerms() []string {
+	terms := []string{i.Project}
+	if searchableItem, ok := i.DefaultItem.(searchable); ok {
+		terms = append(terms, searchableItem.SearchTerms()...)
+	}
+	return terms
+}
+
+// aggregatedResourcesLoadedMsg holds a resource type loaded from every configured project
+type aggregatedResourcesLoadedMsg struct {
+	ResourceType resource.ResourceType
+	Items        []list.Item
+	Errors       map[string]error
+}
+
+// unwrapItem returns the underlying resource item of an all-projects list item
+func unwrapItem(item list.Item) list.Item {
+	if projectItem, ok := item.(projectResourceItem); ok {
+		return projectItem.DefaultItem
+	}
+	return item
+}
+
+// getItemProject returns the project an item was loaded from, or an empty string for the current project
+func getItemProject(item list.Item) string {
+	if projectItem, ok := item.(projectResourceItem); ok {
+		return projectItem.Project
+	}
+	return ""
+}
+
+// getClientForProject returns the client for a project in the all-projects view, or the current client
+func (m *Model) getClientForProject(project string) *hcloud.Client {
+	if client, exists := m.projectClients[project]; exists && project != "" {
+		return client
+	}
+	return m.client
+}
+
+// hasClient reports whether resources can be loaded, either for one project or all of them
+func (m *Model) hasClient() bool {
+	return m.client != nil || m.aggregated
+}
+
+// buildProjectClients creates a client for every configured project
+func (m *Model) buildProjectClients() {
+	m.projectClients = make(map[string]*hcloud.Client, len(m.config.Projects))
+	for _, project := range m.config.Projects {
+		m.projectClients[project.Name] = m.newClient(project.Name, project.Token)
+	}
+}
+
+func (m *Model) enterAggregatedView() tea.Cmd {
+	m.buildProjectClients()
+	m.aggregated = true
+	m.client = nil
+	m.currentProject = ""
+	m.State = stateResourceView
+	m.resetResources()
+	return m.preloadResources()
+}
+
+// resetResources drops everything loaded for the previous project
+func (m *Model) resetResources() {
+	m.endSession()
+	m.clearNavigation()
+	m.topologyCollapsed = nil
+	m.topologyCursor, m.topologyScroll = 0, 0
+	m.metrics = nil
+	m.LoadedResources = make(map[resource.ResourceType]bool)
+	m.Lists = make(map[resource.ResourceType]list.Model)
+	m.projectLoadErrors = nil
+	m.clearMarks()
+	// Label selectors are set for the project they were typed in, so they don't filter the next one
+	m.labelSelectors = nil
+	// Drop the refresh schedules and change highlighting of the previous project
+	m.refreshGenerations = nil
+	m.removedResources = nil
+	m.getResourceCache().Clear()
+	m.getServerIndex().Clear()
+	for _, changes := range m.changedResources {
+		for id := range changes {
+			delete(changes, id)
+		}
+	}
+}
+
+// getAggregatedLoadCmd loads a resource type from every configured project at the same time
+func (m *Model) getAggregatedLoadCmd(ctx context.Context, rt resource.ResourceType) tea.Cmd {
+	clients := m.projectClients
+	labelSelector := m.labelSelectors[rt]
+	serverIndex := m.getServerIndex()
+	return func() tea.Msg {
+		return loadFromProjects(ctx, clients, rt, labelSelector, serverIndex)
+	}
+}
+
+// loadFromProjects loads a resource type with every client concurrently, tagging each item with its project
+func loadFromProjects(ctx context.Context, clients map[string]*hcloud.Client, rt resource.ResourceType, labelSelector string, serverIndex *resource.ServerIndexStore) aggregatedResourcesLoadedMsg {
+	projects := make([]string, 0, len(clients))
+	for project := range clients {
+		projects = append(projects, project)
+	}
+	sort.Strings(projects)
+
+	results := make(map[string]tea.Msg, len(projects))
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+	for _, project := range projects {
+		wg.Add(1)
+		go func(project string, loadCmd tea.Cmd) {
+			defer wg.Done()
+			msg := loadCmd()
+			mu.Lock()
+			results[project] = msg
+			mu.Unlock()
+		}(project, getClientLoadCmd(ctx, clients[project], rt, labelSelector, serverIndex))
+	}
+	wg.Wait()
+
+	loaded := aggregatedResourcesLoadedMsg{
+		ResourceType: rt,
+		Items:        []list.Item{},
+		Errors:       make(map[string]error),
+	}
+	for _, project := range projects {
+		switch msg := results[project].(type) {
+		case message.ErrorMsg:
+			loaded.Errors[project] = msg.Err
+		default:
+			_, items, ok := getResourceItems(msg)
+			if !ok {
+				loaded.Errors[project] = fmt.Errorf("unexpected response while loading %s", strings.ToLower(resource.GetResourceNameFromType(rt)))
+				continue
+			}
+			for _, item := range items {
+				if defaultItem, ok := item.(list.DefaultItem); ok {
+					loaded.Items = append(loaded.Items, projectResourceItem{DefaultItem: defaultItem, Project: project})
+				}
+			}
+		}
+	}
+	return loaded
+}
+
+// switchToProject makes a configured project the current one, dropping everything loaded for the previous one
+func (m *Model) switchToProject(project string) bool {
+	client, exists := m.projectClients[project]
+	if !exists {
+		return false
+	}
+	m.client = client
+	m.currentProject = project
+	m.aggregated = false
+	m.resetResources()
+	return true
+}
+
+// jumpToProjectResource jumps to a resource in another project, switching to that project first if needed
+func (m *Model) jumpToProjectResource(project string, rt resource.ResourceType, id int64) tea.Cmd {
+	if project != "" && !m.aggregated && project != m.currentProject {
+		if !m.switchToProject(project) {
+			m.statusMessage = fmt.Sprintf("⚠️  Project %s is no longer configured", project)
+			return clearStatusMessage()
+		}
+		// The target tab is loaded first, the other tabs of the new project right after it
+		return tea.Batch(m.jumpToResource(rt, id), m.preloadResources())
+	}
+	return m.jumpToResource(rt, id)
+}
+
+func (m Model) renderProjectLoadErrors() string {
+	projectErrors := m.projectLoadErrors[m.activeTab]
+	if len(projectErrors) == 0 {
+		return ""
+	}
+	projects := make([]string, 0, len(projectErrors))
+	for project := range projectErrors {
+		projects = append(projects, project)
+	}
+	sort.Strings(projects)
+
+	lines := make([]string, 0, len(projects))
+	for _, project := range projects {
+		lines = append(lines, errorStyle.Render(fmt.Sprintf("⚠️  %s: %v", project, projectErrors[project])))
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
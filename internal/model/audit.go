@@ -0,0 +1,170 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/grammeaway/lazyhetzner/internal/audit"
+	"github.com/grammeaway/lazyhetzner/internal/bulk"
+)
+
+// Lines taken by the breadcrumbs, title, header, filters and help of the audit log view
+const auditChromeHeight = 10
+
+// openAuditLog shows the write operations recorded in the audit log, starting with the current project's
+func (m *Model) openAuditLog() tea.Cmd {
+	m.auditEntries, m.auditErr, m.auditLoading = nil, nil, true
+	m.auditFilter = audit.Filter{ByProject: !m.aggregated, Project: m.currentProject}
+	m.auditFilterInput = textinput.New()
+	m.auditFilterInput.Placeholder = "resource type, name or ID"
+	m.auditFilterInput.CharLimit = 128
+	m.auditFilterInput.Width = min(40, m.width-10)
+	return tea.Batch(m.navigate(page{State: stateAuditView, Tab: m.activeTab}), audit.LoadCmd())
+}
+
+func (m *Model) reloadAuditLog() tea.Cmd {
+	m.auditLoading = true
+	return audit.LoadCmd()
+}
+
+func (m Model) getAuditEntries() []audit.Entry {
+	var entries []audit.Entry
+	for _, entry := range m.auditEntries {
+		if m.auditFilter.Matches(entry) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// cycleAuditProject switches the project the log is filtered by, with all projects before the first one
+func (m *Model) cycleAuditProject(offset int) {
+	projects := audit.Projects(m.auditEntries)
+	if m.auditFilter.ByProject && !slices.Contains(projects, m.auditFilter.Project) {
+		projects = append(projects, m.auditFilter.Project)
+		sort.Strings(projects)
+	}
+	current := 0
+	if m.auditFilter.ByProject {
+		current = slices.Index(projects, m.auditFilter.Project) + 1
+	}
+	next := (current + offset + len(projects) + 1) % (len(projects) + 1)
+	m.auditFilter.ByProject = next > 0
+	if next > 0 {
+		m.auditFilter.Project = projects[next-1]
+	}
+	m.auditScroll = 0
+}
+
+func (m *Model) focusAuditFilter() tea.Cmd {
+	m.auditFilterInput.Focus()
+	return textinput.Blink
+}
+
+// updateAuditFilter passes keys to the resource filter while it is focused, filtering as it is typed
+func (m *Model) updateAuditFilter(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	m.auditFilterInput, cmd = m.auditFilterInput.Update(msg)
+	if m.auditFilter.Resource != m.auditFilterInput.Value() {
+		m.auditFilter.Resource = m.auditFilterInput.Value()
+		m.auditScroll = 0
+	}
+	return cmd
+}
+
+func (m *Model) scrollAuditLog(offset int) {
+	maxScroll := max(0, len(m.getAuditEntries())-m.getAuditBodyHeight())
+	m.auditScroll = max(0, min(maxScroll, m.auditScroll+offset))
+}
+
+func (m Model) getAuditBodyHeight() int {
+	return max(5, m.height-auditChromeHeight)
+}
+
+func formatAuditProject(project string) string {
+	if project == "" {
+		return "one-time access"
+	}
+	return project
+}
+
+func formatAuditEntry(entry audit.Entry) string {
+	result := successStyle.Render("✅")
+	if entry.Result != audit.ResultSuccess {
+		result = errorStyle.Render("❌")
+	}
+	line := fmt.Sprintf("%s %s [%s] %s %s %s (ID: %d)",
+		result,
+		entry.Time.Local().Format("2006-01-02 15:04:05"),
+		formatAuditProject(entry.Project),
+		bulk.GetActionLabel(entry.Action),
+		entry.ResourceType,
+		entry.ResourceName,
+		entry.ResourceID,
+	)
+	if len(entry.Params) > 0 {
+		keys := make([]string, 0, len(entry.Params))
+		for key := range entry.Params {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		params := make([]string, 0, len(keys))
+		for _, key := range keys {
+			params = append(params, fmt.Sprintf("%s=%s", key, entry.Params[key]))
+		}
+		line += " " + helpStyle.Render(strings.Join(params, " "))
+	}
+	if entry.Error != "" {
+		line += " " + errorStyle.Render(entry.Error)
+	}
+	return line
+}
+
+func (m Model) renderAuditLog() string {
+	var auditView strings.Builder
+	auditView.WriteString(m.renderBreadcrumbs() + "\n\n")
+	auditView.WriteString(fmt.Sprintf("%s\n\n", titleStyle.Render("Audit Log")))
+	path, err := audit.Path()
+	if err != nil {
+		path = "the lazyhetzner config directory"
+	}
+	auditView.WriteString(infoStyle.Render("📝 Write operations performed through lazyhetzner, newest first, as recorded in "+path) + "\n\n")
+
+	projectFilter := "all projects"
+	if m.auditFilter.ByProject {
+		projectFilter = formatAuditProject(m.auditFilter.Project)
+	}
+	auditView.WriteString(fmt.Sprintf("Project: %s • Resource: %s\n\n", projectFilter, m.auditFilterInput.View()))
+
+	entries := m.getAuditEntries()
+	visible := m.getAuditBodyHeight()
+	start := min(m.auditScroll, max(0, len(entries)-visible))
+	end := min(len(entries), start+visible)
+	switch {
+	case m.auditLoading:
+		auditView.WriteString("Loading the audit log...\n")
+	case m.auditErr != nil:
+		auditView.WriteString(errorStyle.Render(fmt.Sprintf("⚠️  Reading the audit log failed: %v", m.auditErr)) + "\n")
+	case m.auditEntries == nil:
+		auditView.WriteString(noDetailsStyle.Render("No write operations recorded yet.") + "\n")
+	case len(entries) == 0:
+		auditView.WriteString(noDetailsStyle.Render("No entries match the filters.") + "\n")
+	}
+	for _, entry := range entries[start:end] {
+		auditView.WriteString(lipgloss.NewStyle().MaxWidth(max(0, m.width-2)).Render(formatAuditEntry(entry)) + "\n")
+	}
+
+	helpText := "💡 ←/→: project • f: filter by resource • r: reload • q/[: back"
+	if m.auditFilterInput.Focused() {
+		helpText = "💡 Type to filter by resource type, name or ID • Enter/Esc: done"
+	} else if len(entries) > visible {
+		helpText += fmt.Sprintf(" • ↑/↓: scroll (entries %d-%d of %d)", start+1, end, len(entries))
+	}
+	auditView.WriteString(helpStyle.Render(helpText))
+	return auditView.String()
+}
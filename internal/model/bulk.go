@@ -0,0 +1,315 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/grammeaway/lazyhetzner/internal/bulk"
+	ctm "github.com/grammeaway/lazyhetzner/internal/context_menu"
+	"github.com/grammeaway/lazyhetzner/internal/registry"
+	"github.com/grammeaway/lazyhetzner/internal/resource"
+)
+
+// resourceDelegate renders items like the default delegate, but flags items marked for bulk actions
+// and items that were added or changed by the last refresh
+type resourceDelegate struct {
+	list.DefaultDelegate
+	marked  map[int64]bool
+	changes map[int64]changeKind
+}
+
+// flaggedItem prefixes the description of an item with its flags
+type flaggedItem struct {
+	list.DefaultItem
+	prefix string
+}
+
+func (i flaggedItem) Description() string { return i.prefix + i.DefaultItem.Description() }
+
+func (d resourceDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	id, ok := getItemResourceID(item)
+	defaultItem, isDefaultItem := item.(list.DefaultItem)
+	if !ok || !isDefaultItem {
+		d.DefaultDelegate.Render(w, m, index, item)
+		return
+	}
+
+	prefix := ""
+	switch d.changes[id] {
+	case changeAdded:
+		prefix = "✚ new | "
+		d.Styles.NormalTitle = d.Styles.NormalTitle.Foreground(lipgloss.Color("#00FF88"))
+		d.Styles.SelectedTitle = d.Styles.SelectedTitle.Foreground(lipgloss.Color("#00FF88"))
+	case changeUpdated:
+		prefix = "● changed | "
+		d.Styles.NormalTitle = d.Styles.NormalTitle.Foreground(lipgloss.Color("#00AAFF"))
+		d.Styles.SelectedTitle = d.Styles.SelectedTitle.Foreground(lipgloss.Color("#00AAFF"))
+	}
+	if d.marked[id] {
+		prefix = "✔ marked | " + prefix
+		d.Styles.NormalTitle = d.Styles.NormalTitle.Foreground(lipgloss.Color("#FFAA00"))
+		d.Styles.SelectedTitle = d.Styles.SelectedTitle.Foreground(lipgloss.Color("#FFAA00"))
+	}
+	if prefix != "" {
+		item = flaggedItem{DefaultItem: defaultItem, prefix: prefix}
+	}
+	d.DefaultDelegate.Render(w, m, index, item)
+}
+
+func getItemResourceID(item list.Item) (int64, bool) {
+	return registry.GetItemResourceID(unwrapItem(item))
+}
+
+// getBulkTarget returns the bulk target of a list item, if its kind supports bulk actions
+func (m *Model) getBulkTarget(rt resource.ResourceType, item list.Item) (bulk.Target, bool) {
+	kind, bulkKind, ok := registry.GetBulkKind(rt)
+	if !ok {
+		return bulk.Target{}, false
+	}
+	unwrapped := unwrapItem(item)
+	id, ok := kind.ItemID(unwrapped)
+	if !ok {
+		return bulk.Target{}, false
+	}
+	target := bulk.Target{
+		ResourceType: rt,
+		ID:           id,
+		Name:         bulkKind.ItemName(unwrapped),
+		Project:      m.currentProject,
+		// The client is kept with the target, so runs and retries reach the project the resource belongs to
+		// even after switching projects
+		Client: m.client,
+	}
+	if project := getItemProject(item); project != "" {
+		target.Project = project
+		target.Client = m.getClientForProject(project)
+		target.FromAllProjects = true
+	}
+	return target, true
+}
+
+// refuseBulk tells that the resources of a tab can't be marked, reporting whether they can
+func (m *Model) refuseBulk(rt resource.ResourceType) bool {
+	if _, _, ok := registry.GetBulkKind(rt); ok {
+		return false
+	}
+	m.statusMessage = fmt.Sprintf("⚠️  Bulk actions aren't available for %s", resource.GetResourceNameFromType(rt))
+	return true
+}
+
+// getMarkedSet returns the set of marked resource IDs for a tab, creating it if needed.
+// The set is shared with the tab's list delegate, so it must never be replaced.
+func (m *Model) getMarkedSet(rt resource.ResourceType) map[int64]bool {
+	if m.markedResources == nil {
+		m.markedResources = make(map[resource.ResourceType]map[int64]bool)
+	}
+	marked, exists := m.markedResources[rt]
+	if !exists {
+		marked = make(map[int64]bool)
+		m.markedResources[rt] = marked
+	}
+	return marked
+}
+
+func (m *Model) clearMarks() {
+	for _, marked := range m.markedResources {
+		for id := range marked {
+			delete(marked, id)
+		}
+	}
+}
+
+// newResourceList creates the list for a tab, dropping marks for resources that no longer exist
+func (m *Model) newResourceList(rt resource.ResourceType, items []list.Item) list.Model {
+	marked := m.getMarkedSet(rt)
+	present := make(map[int64]bool, len(items))
+	for _, item := range items {
+		if id, ok := getItemResourceID(item); ok {
+			present[id] = true
+		}
+	}
+	for id := range marked {
+		if !present[id] {
+			delete(marked, id)
+		}
+	}
+
+	resourceList := list.New(m.sortItems(rt, items), m.newResourceDelegate(rt), m.getListWidth(), m.height-10)
+	resourceList.Title = resource.GetResourceNameFromType(rt)
+	// '/' opens the global search, so the list's own filter moves to 'f'
+	resourceList.KeyMap.Filter = key.NewBinding(key.WithKeys("f"), key.WithHelp("f", "filter"))
+	resourceList.KeyMap.NextPage = key.NewBinding(key.WithKeys("right", "l", "pgdown", "d"), key.WithHelp("→/l/pgdn", "next page"))
+	m.styleResourceList(rt, &resourceList)
+
+	if m.pendingSelection != nil && m.pendingSelection.ResourceType == rt {
+		selectResource(&resourceList, m.pendingSelection.ResourceID)
+		m.pendingSelection = nil
+	}
+	return resourceList
+}
+
+func (m *Model) toggleMarkSelected() {
+	currentList, exists := m.Lists[m.activeTab]
+	if !exists || m.refuseBulk(m.activeTab) {
+		return
+	}
+	id, ok := getItemResourceID(currentList.SelectedItem())
+	if !ok {
+		return
+	}
+	marked := m.getMarkedSet(m.activeTab)
+	if marked[id] {
+		delete(marked, id)
+	} else {
+		marked[id] = true
+	}
+	currentList.CursorDown()
+	m.Lists[m.activeTab] = currentList
+}
+
+// toggleMarkVisible marks every item matching the current filter, or unmarks them if all are already marked
+func (m *Model) toggleMarkVisible() {
+	currentList, exists := m.Lists[m.activeTab]
+	if !exists || m.refuseBulk(m.activeTab) {
+		return
+	}
+	marked := m.getMarkedSet(m.activeTab)
+	ids := []int64{}
+	allMarked := true
+	for _, item := range currentList.VisibleItems() {
+		if id, ok := getItemResourceID(item); ok {
+			ids = append(ids, id)
+			allMarked = allMarked && marked[id]
+		}
+	}
+	for _, id := range ids {
+		if allMarked {
+			delete(marked, id)
+		} else {
+			marked[id] = true
+		}
+	}
+}
+
+func (m *Model) getMarkedTargets(rt resource.ResourceType) []bulk.Target {
+	targets := []bulk.Target{}
+	currentList, exists := m.Lists[rt]
+	if !exists {
+		return targets
+	}
+	marked := m.getMarkedSet(rt)
+	for _, item := range currentList.Items() {
+		if target, ok := m.getBulkTarget(rt, item); ok && marked[target.ID] {
+			targets = append(targets, target)
+		}
+	}
+	return targets
+}
+
+func (m *Model) openBulkMenu() {
+	if m.refuseBulk(m.activeTab) {
+		return
+	}
+	targets := m.getMarkedTargets(m.activeTab)
+	if len(targets) == 0 {
+		m.statusMessage = "⚠️  No resources marked - press space to mark resources"
+		return
+	}
+	items := bulk.GetBulkMenuItems(m.activeTab)
+	if m.hasReadOnlyTarget(targets) {
+		items = ctm.WithoutMutating(items)
+		if len(items) <= 1 {
+			m.statusMessage = "🔒 Bulk actions are not available in read-only projects"
+			return
+		}
+	}
+	m.bulkTargets = targets
+	m.bulkMenu = ctm.ContextMenu{
+		Items:        items,
+		SelectedItem: 0,
+		ResourceType: m.activeTab,
+	}
+	m.State = stateBulkMenu
+}
+
+func (m *Model) selectBulkAction(action string) tea.Cmd {
+	if action == "cancel" {
+		m.State = stateResourceView
+		return nil
+	}
+	m.bulkAction = action
+
+	prompt := bulk.GetInputPrompt(m.bulkMenu.ResourceType, action, len(m.bulkTargets))
+	if prompt == "" {
+		return m.confirmBulkAction(m.bulkMenu.ResourceType, action, "", m.bulkTargets)
+	}
+
+	m.bulkInput = textinput.New()
+	m.bulkInput.Placeholder = prompt
+	m.bulkInput.CharLimit = 128
+	m.bulkInput.Width = min(70, m.width-10)
+	m.bulkInput.Focus()
+	m.State = stateBulkInput
+	return textinput.Blink
+}
+
+// confirmBulkAction runs a bulk action, asking for the names of the protected projects first if it is destructive
+func (m *Model) confirmBulkAction(rt resource.ResourceType, action string, param string, targets []bulk.Target) tea.Cmd {
+	item, _ := ctm.FindItem(bulk.GetBulkMenuItems(rt), action)
+	return m.confirmDestructive(item, m.getProtectedTargetProjects(targets), len(targets), func(m *Model) tea.Cmd {
+		return m.runBulkAction(rt, action, param, targets)
+	})
+}
+
+func (m *Model) runBulkAction(rt resource.ResourceType, action string, param string, targets []bulk.Target) tea.Cmd {
+	m.State = stateResourceView
+	if item, found := ctm.FindItem(bulk.GetBulkMenuItems(rt), action); found && item.Mutating && m.hasReadOnlyTarget(targets) {
+		return m.refuseReadOnly(item.Label)
+	}
+	m.statusMessage = fmt.Sprintf("⏳ %s: running on %d resource(s)...", bulk.GetActionLabel(action), len(targets))
+	client := m.client
+	return m.withActionContext(func(ctx context.Context) tea.Cmd {
+		return bulk.RunBulkAction(ctx, client, rt, action, param, targets)
+	})
+}
+
+// retryBulkAction runs the action of the last bulk results again on the failed targets. Actions that
+// ask for a parameter or a confirmation ask again, since the number of targets changed.
+func (m *Model) retryBulkAction() tea.Cmd {
+	if m.bulkResults == nil {
+		return nil
+	}
+	failed := m.bulkResults.Failed()
+	if len(failed) == 0 {
+		return nil
+	}
+	rt := m.bulkResults.ResourceType
+	m.bulkTargets = failed
+	m.bulkMenu = ctm.ContextMenu{
+		Items:        bulk.GetBulkMenuItems(rt),
+		SelectedItem: 0,
+		ResourceType: rt,
+	}
+	return m.selectBulkAction(m.bulkResults.Action)
+}
+
+// ownsBulkResults reports whether bulk results belong to the project, or all-projects view, shown now
+func (m Model) ownsBulkResults(msg bulk.BulkActionCompletedMsg) bool {
+	for _, result := range msg.Results {
+		if result.Target.FromAllProjects != m.aggregated || (!m.aggregated && result.Target.Project != m.currentProject) {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *Model) isFiltering() bool {
+	currentList, exists := m.Lists[m.activeTab]
+	return exists && currentList.FilterState() == list.Filtering
+}
@@ -0,0 +1,181 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/grammeaway/lazyhetzner/internal/cost"
+	"github.com/grammeaway/lazyhetzner/internal/resource"
+)
+
+// Lines taken by the breadcrumbs, title, header and help of the cost view
+const costChromeHeight = 8
+
+// Characters that don't belong in the name of an exported file
+var unsafeFileNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// openCostEstimate estimates the monthly cost of the project's loaded resources from the current prices
+func (m *Model) openCostEstimate() tea.Cmd {
+	if m.aggregated || m.client == nil {
+		m.statusMessage = "⚠️  Costs are estimated per project, open a single project to see them"
+		return clearStatusMessage()
+	}
+	return tea.Batch(m.navigate(page{State: stateCostView, Tab: m.activeTab}), m.loadCostEstimate())
+}
+
+func (m *Model) loadCostEstimate() tea.Cmd {
+	m.costEstimate = nil
+	m.statusMessage = "💰 Loading prices..."
+	client, snapshot := m.client, cost.Collect(m.getRelatedItems)
+	// Primary IPs are the public addresses of servers, so they follow the label selector of the servers tab
+	primaryIPSelector := m.labelSelectors[resource.ResourceServers]
+	return m.withRequestContext(func(ctx context.Context) tea.Cmd {
+		return cost.LoadEstimate(ctx, client, snapshot, primaryIPSelector)
+	})
+}
+
+// cycleCostLabelKey switches the label the costs are broken down by
+func (m *Model) cycleCostLabelKey(offset int) {
+	if m.costEstimate == nil {
+		return
+	}
+	labelKeys := m.costEstimate.LabelKeys()
+	if len(labelKeys) == 0 {
+		return
+	}
+	current := max(0, slices.Index(labelKeys, m.getCostLabelKey()))
+	m.costLabelKey = labelKeys[(current+offset+len(labelKeys))%len(labelKeys)]
+}
+
+// getCostLabelKey returns the label the costs are broken down by, defaulting to the first one in use
+func (m Model) getCostLabelKey() string {
+	if m.costEstimate == nil {
+		return ""
+	}
+	labelKeys := m.costEstimate.LabelKeys()
+	if slices.Contains(labelKeys, m.costLabelKey) || len(labelKeys) == 0 {
+		return m.costLabelKey
+	}
+	return labelKeys[0]
+}
+
+// exportCostEstimate writes the estimate to a CSV file in the working directory
+func (m *Model) exportCostEstimate() tea.Cmd {
+	if m.costEstimate == nil {
+		return nil
+	}
+	project := unsafeFileNameChars.ReplaceAllString(m.currentProject, "-")
+	path := fmt.Sprintf("lazyhetzner-costs-%s-%s.csv", project, time.Now().Format("20060102-150405"))
+	return cost.ExportCSVCmd(*m.costEstimate, path)
+}
+
+func (m *Model) scrollCostView(offset int) {
+	maxScroll := max(0, len(m.renderCostBody())-m.getCostBodyHeight())
+	m.costScroll = max(0, min(maxScroll, m.costScroll+offset))
+}
+
+func (m Model) getCostBodyHeight() int {
+	return max(5, m.height-costChromeHeight)
+}
+
+func (m Model) formatCost(amount cost.Amount, hourly bool) string {
+	if hourly {
+		return fmt.Sprintf("%.4f %s", amount.Gross, m.costEstimate.Currency)
+	}
+	return fmt.Sprintf("%.2f %s", amount.Gross, m.costEstimate.Currency)
+}
+
+func (m Model) formatCostGroups(groups []cost.Group) []string {
+	lines := make([]string, 0, len(groups))
+	for _, group := range groups {
+		lines = append(lines, fmt.Sprintf("• %s (%d): %s/mo | %s/h", group.Key, group.Count, m.formatCost(group.Monthly, false), m.formatCost(group.Hourly, true)))
+	}
+	return lines
+}
+
+// renderCostBody renders the breakdowns of the estimate as a grid, split into lines for scrolling
+func (m Model) renderCostBody() []string {
+	if m.costEstimate == nil {
+		return nil
+	}
+	estimate := *m.costEstimate
+	total := estimate.Total()
+	totalLines := []string{
+		fmt.Sprintf("Monthly: %s (%.2f net)", m.formatCost(total.Monthly, false), total.Monthly.Net),
+		fmt.Sprintf("Hourly: %s (%.4f net)", m.formatCost(total.Hourly, true), total.Hourly.Net),
+		fmt.Sprintf("Resources: %d", total.Count),
+	}
+	if unpriced := estimate.Unpriced(); unpriced > 0 {
+		totalLines = append(totalLines, fmt.Sprintf("⚠️  %d resource(s) without a price", unpriced))
+	}
+
+	labelTitle := "By Label"
+	labelLines := []string{"No labels in use."}
+	if labelKey := m.getCostLabelKey(); labelKey != "" {
+		labelTitle = fmt.Sprintf("By Label: %s", labelKey)
+		labelLines = m.formatCostGroups(estimate.GroupBy(cost.ByLabel(labelKey)))
+	}
+
+	items := slices.Clone(estimate.Lines)
+	slices.SortStableFunc(items, func(a, b cost.Line) int {
+		switch {
+		case a.Monthly.Gross > b.Monthly.Gross:
+			return -1
+		case a.Monthly.Gross < b.Monthly.Gross:
+			return 1
+		}
+		return 0
+	})
+	itemLines := make([]string, 0, len(items))
+	for _, item := range items {
+		price := m.formatCost(item.Monthly, false) + "/mo"
+		if !item.Priced {
+			price = "no price"
+		}
+		itemLines = append(itemLines, fmt.Sprintf("• %s %s (%s, %s): %s", item.Category, item.Name, item.Detail, item.Location, price))
+	}
+
+	columns, columnWidth, gap := detailGridLayout(m.width)
+	sections := []string{
+		renderDetailSection("Total", totalLines, columnWidth),
+		renderDetailSection("By Resource Type", m.formatCostGroups(estimate.GroupBy(cost.ByCategory)), columnWidth),
+		renderDetailSection("By Location", m.formatCostGroups(estimate.GroupBy(cost.ByLocation)), columnWidth),
+		renderDetailSection(labelTitle, labelLines, columnWidth),
+		renderDetailSection("Resources", itemLines, columnWidth),
+	}
+	return strings.Split(renderDetailGrid(sections, columns, gap), "\n")
+}
+
+func (m Model) renderCostView() string {
+	var costView strings.Builder
+	costView.WriteString(m.renderBreadcrumbs() + "\n\n")
+	costView.WriteString(fmt.Sprintf("%s\n\n", titleStyle.Render("Cost Estimate")))
+	costView.WriteString(infoStyle.Render(fmt.Sprintf("💰 Estimated cost of the loaded resources of %s, incl. VAT at current prices", m.currentProject)) + "\n")
+	if selector := m.labelSelectors[resource.ResourceServers]; selector != "" {
+		costView.WriteString(infoStyle.Render(fmt.Sprintf("🏷️  Primary IPs are filtered like the servers: %s", selector)) + "\n")
+	}
+	costView.WriteString("\n")
+
+	body := m.renderCostBody()
+	visible := m.getCostBodyHeight()
+	start := min(m.costScroll, max(0, len(body)-visible))
+	end := min(len(body), start+visible)
+	if len(body) > 0 {
+		costView.WriteString(strings.Join(body[start:end], "\n") + "\n")
+	}
+
+	helpText := "💡 ←/→: label to break down by • e: export as CSV • r: reload prices • q/[: back"
+	if len(body) > visible {
+		helpText += fmt.Sprintf(" • ↑/↓: scroll (lines %d-%d of %d)", start+1, end, len(body))
+	}
+	if m.statusMessage != "" {
+		costView.WriteString(successStyle.Render(m.statusMessage) + "\n")
+	}
+	costView.WriteString(helpStyle.Render(helpText))
+	return costView.String()
+}
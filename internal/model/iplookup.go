@@ -0,0 +1,144 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/grammeaway/lazyhetzner/internal/iplookup"
+)
+
+func (m *Model) openIPLookup() tea.Cmd {
+	m.ipLookupInput = textinput.New()
+	m.ipLookupInput.Placeholder = "IPv4/IPv6 address or CIDR, e.g. 10.0.1.7 or 2a01:4f8::/64"
+	m.ipLookupInput.CharLimit = 64
+	m.ipLookupInput.Width = min(70, m.width-10)
+	m.ipLookupInput.Focus()
+	m.ipLookupResult = nil
+	m.ipLookupCursor = 0
+	m.State = stateIPLookup
+	return textinput.Blink
+}
+
+// lookupsAllProjects reports whether IP lookups search every configured project
+func (m Model) lookupsAllProjects() bool {
+	return m.aggregated || m.ipLookupAllProjects
+}
+
+// toggleIPLookupScope switches the lookup between the current project and all configured projects
+func (m *Model) toggleIPLookupScope() tea.Cmd {
+	if m.aggregated || m.config == nil || len(m.config.Projects) == 0 {
+		return nil
+	}
+	m.ipLookupAllProjects = !m.ipLookupAllProjects
+	m.ipLookupResult = nil
+	m.ipLookupCursor = 0
+	if strings.TrimSpace(m.ipLookupInput.Value()) == "" {
+		return nil
+	}
+	return m.submitIPLookup()
+}
+
+// submitIPLookup runs a new lookup if the query changed, otherwise it jumps to the selected owner
+func (m *Model) submitIPLookup() tea.Cmd {
+	query := strings.TrimSpace(m.ipLookupInput.Value())
+	if query == "" {
+		return nil
+	}
+	prefix, err := iplookup.ParseQuery(query)
+	if err != nil {
+		m.statusMessage = "⚠️  " + err.Error()
+		return clearStatusMessage()
+	}
+
+	if m.ipLookupResult != nil && m.ipLookupResult.Query == prefix.String() {
+		if m.ipLookupCursor < len(m.ipLookupResult.Owners) {
+			owner := m.ipLookupResult.Owners[m.ipLookupCursor]
+			if owner.ResourceID != 0 {
+				m.ipLookupInput.Blur()
+				return m.jumpToProjectResource(owner.Project, owner.ResourceType, owner.ResourceID)
+			}
+		}
+		return nil
+	}
+
+	m.statusMessage = "🔍 Looking up " + prefix.String() + "..."
+	if m.lookupsAllProjects() {
+		if !m.aggregated {
+			m.buildProjectClients()
+		}
+		clients := m.projectClients
+		return m.withRequestContext(func(ctx context.Context) tea.Cmd {
+			return iplookup.LookupIPInProjects(ctx, clients, query)
+		})
+	}
+	client := m.client
+	return m.withRequestContext(func(ctx context.Context) tea.Cmd {
+		return iplookup.LookupIP(ctx, client, query)
+	})
+}
+
+func (m Model) renderIPLookup() string {
+	var lookupView strings.Builder
+	lookupView.WriteString(fmt.Sprintf("%s\n\n", titleStyle.Render("IP Address Lookup")))
+	scope := "this project"
+	if m.lookupsAllProjects() {
+		scope = "all projects"
+	}
+	lookupView.WriteString(infoStyle.Render("Find what owns an address or range in "+scope) + "\n\n")
+	lookupView.WriteString(m.ipLookupInput.View() + "\n\n")
+
+	if m.statusMessage != "" {
+		lookupView.WriteString(warningStyle.Render(m.statusMessage) + "\n\n")
+	}
+
+	if m.ipLookupResult != nil {
+		projects := make([]string, 0, len(m.ipLookupResult.Errors))
+		for project := range m.ipLookupResult.Errors {
+			projects = append(projects, project)
+		}
+		sort.Strings(projects)
+		for _, project := range projects {
+			lookupView.WriteString(errorStyle.Render(fmt.Sprintf("⚠️  %s: %v", project, m.ipLookupResult.Errors[project])) + "\n")
+		}
+		if len(projects) > 0 {
+			lookupView.WriteString("\n")
+		}
+
+		if len(m.ipLookupResult.Owners) == 0 {
+			noOwnersMsg := fmt.Sprintf("⚠️  Nothing in %s owns or contains %s", scope, m.ipLookupResult.Query)
+			lookupView.WriteString(noLabelsStyle.Render(noOwnersMsg) + "\n")
+		} else {
+			var ownersContent strings.Builder
+			ownersContent.WriteString(fmt.Sprintf("Found %d owner(s) of %s:\n\n", len(m.ipLookupResult.Owners), m.ipLookupResult.Query))
+			for i, owner := range m.ipLookupResult.Owners {
+				line := fmt.Sprintf("%s: %s (ID: %d) | %s | %s", owner.Kind, owner.Name, owner.ID, owner.Address, owner.Detail)
+				if owner.Project != "" {
+					line = fmt.Sprintf("[%s] %s", owner.Project, line)
+				}
+				if i == m.ipLookupCursor {
+					line = selectedMenuStyle.Render(line)
+				} else {
+					line = "  " + line
+				}
+				ownersContent.WriteString(line)
+				if i < len(m.ipLookupResult.Owners)-1 {
+					ownersContent.WriteString("\n")
+				}
+			}
+			lookupView.WriteString(labelContainerStyle.Render(ownersContent.String()) + "\n")
+		}
+	}
+
+	helpText := "💡 Enter: look up / jump to selected resource • ↑/↓: navigate results • Tab: search all projects • Esc: return to resource view"
+	if m.aggregated {
+		helpText = "💡 Enter: look up / jump to selected resource • ↑/↓: navigate results • Esc: return to resource view"
+	} else if m.ipLookupAllProjects {
+		helpText = "💡 Enter: look up / switch project and jump to selected resource • ↑/↓: navigate results • Tab: current project only • Esc: return to resource view"
+	}
+	lookupView.WriteString("\n" + helpStyle.Render(helpText))
+	return lookupView.String()
+}
@@ -21,6 +21,36 @@ type keyMap struct {
 	Help               key.Binding
 	Reload             key.Binding
 	Details            key.Binding
+	Mark               key.Binding
+	MarkAll            key.Binding
+	BulkActions        key.Binding
+	Retry              key.Binding
+	LabelSelector      key.Binding
+	Search             key.Binding
+	IPLookup           key.Binding
+	AllProjects        key.Binding
+	AutoRefresh        key.Binding
+	Back               key.Binding
+	Forward            key.Binding
+	PageUp             key.Binding
+	PageDown           key.Binding
+	SortOrder          key.Binding
+	Preview            key.Binding
+	TableMode          key.Binding
+	ColumnLeft         key.Binding
+	ColumnRight        key.Binding
+	SortColumn         key.Binding
+	WidenColumn        key.Binding
+	NarrowColumn       key.Binding
+	HideColumn         key.Binding
+	ShowColumns        key.Binding
+	Topology           key.Binding
+	CostEstimate       key.Binding
+	Export             key.Binding
+	TimeRange          key.Binding
+	AuditLog           key.Binding
+	AuditFilter        key.Binding
+	SkipVault          key.Binding
 
 	Num1 key.Binding
 	Num2 key.Binding
@@ -41,8 +71,10 @@ func (k keyMap) ShortHelp() []key.Binding {
 func (k keyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
 		{k.Up, k.Down, k.Left, k.Right},
-		{k.Tab, k.Enter, k.Add, k.Delete, k.SetDefaultProject, k.SetDefaultTerminal, k.Details},
-		{k.Help, k.Quit},
+		{k.Tab, k.Enter, k.Add, k.Delete, k.SetDefaultProject, k.SetDefaultTerminal, k.Details, k.AllProjects},
+		{k.Mark, k.MarkAll, k.BulkActions, k.Retry, k.LabelSelector, k.Search, k.IPLookup, k.AutoRefresh},
+		{k.SortOrder, k.Preview, k.TableMode, k.ColumnLeft, k.ColumnRight, k.SortColumn, k.WidenColumn, k.NarrowColumn, k.HideColumn, k.ShowColumns, k.Topology, k.CostEstimate},
+		{k.Back, k.Forward, k.PageUp, k.PageDown, k.TimeRange, k.AuditLog, k.AuditFilter, k.Help, k.Quit},
 	}
 }
 
@@ -105,9 +137,129 @@ var keys = keyMap{
 	),
 	Details: key.NewBinding(
 		key.WithKeys("i"),
-		key.WithHelp("i", "server details"),
+		key.WithHelp("i", "details"),
+	),
+	Mark: key.NewBinding(
+		key.WithKeys(" "),
+		key.WithHelp("space", "mark/unmark"),
+	),
+	MarkAll: key.NewBinding(
+		key.WithKeys("A"),
+		key.WithHelp("A", "mark all matching filter"),
+	),
+	BulkActions: key.NewBinding(
+		key.WithKeys("x"),
+		key.WithHelp("x", "bulk actions"),
+	),
+	Retry: key.NewBinding(
+		key.WithKeys("r"),
+		key.WithHelp("r", "retry failed"),
+	),
+	LabelSelector: key.NewBinding(
+		key.WithKeys("L"),
+		key.WithHelp("L", "filter by label selector"),
+	),
+	Search: key.NewBinding(
+		key.WithKeys("/"),
+		key.WithHelp("/", "search all resources"),
+	),
+	IPLookup: key.NewBinding(
+		key.WithKeys("w"),
+		key.WithHelp("w", "who owns this IP"),
+	),
+	AllProjects: key.NewBinding(
+		key.WithKeys("m"),
+		key.WithHelp("m", "view all projects"),
+	),
+	AutoRefresh: key.NewBinding(
+		key.WithKeys("R"),
+		key.WithHelp("R", "cycle auto-refresh interval"),
+	),
+	Back: key.NewBinding(
+		key.WithKeys("backspace", "["),
+		key.WithHelp("[", "go back"),
+	),
+	Forward: key.NewBinding(
+		key.WithKeys("]"),
+		key.WithHelp("]", "go forward"),
+	),
+	PageUp: key.NewBinding(
+		key.WithKeys("pgup"),
+		key.WithHelp("pgup", "scroll up"),
+	),
+	PageDown: key.NewBinding(
+		key.WithKeys("pgdown"),
+		key.WithHelp("pgdn", "scroll down"),
+	),
+	SortOrder: key.NewBinding(
+		key.WithKeys("o"),
+		key.WithHelp("o", "cycle sort order"),
+	),
+	Preview: key.NewBinding(
+		key.WithKeys("v"),
+		key.WithHelp("v", "toggle preview pane"),
+	),
+	TableMode: key.NewBinding(
+		key.WithKeys("T"),
+		key.WithHelp("T", "toggle table view"),
+	),
+	ColumnLeft: key.NewBinding(
+		key.WithKeys("<"),
+		key.WithHelp("<", "previous column"),
+	),
+	ColumnRight: key.NewBinding(
+		key.WithKeys(">"),
+		key.WithHelp(">", "next column"),
+	),
+	SortColumn: key.NewBinding(
+		key.WithKeys("s"),
+		key.WithHelp("s", "sort by column"),
+	),
+	WidenColumn: key.NewBinding(
+		key.WithKeys("+", "="),
+		key.WithHelp("+", "widen column"),
+	),
+	NarrowColumn: key.NewBinding(
+		key.WithKeys("-"),
+		key.WithHelp("-", "narrow column"),
+	),
+	HideColumn: key.NewBinding(
+		key.WithKeys("H"),
+		key.WithHelp("H", "hide column"),
+	),
+	ShowColumns: key.NewBinding(
+		key.WithKeys("C"),
+		key.WithHelp("C", "show all columns"),
 	),
 
+	Topology: key.NewBinding(
+		key.WithKeys("M"),
+		key.WithHelp("M", "topology"),
+	),
+	CostEstimate: key.NewBinding(
+		key.WithKeys("$"),
+		key.WithHelp("$", "cost estimate"),
+	),
+	Export: key.NewBinding(
+		key.WithKeys("e"),
+		key.WithHelp("e", "export as CSV"),
+	),
+	TimeRange: key.NewBinding(
+		key.WithKeys("t"),
+		key.WithHelp("t", "metrics time range"),
+	),
+	AuditLog: key.NewBinding(
+		key.WithKeys("a"),
+		key.WithHelp("a", "audit log"),
+	),
+	AuditFilter: key.NewBinding(
+		key.WithKeys("f"),
+		key.WithHelp("f", "filter the audit log"),
+	),
+	SkipVault: key.NewBinding(
+		key.WithKeys("ctrl+s"),
+		key.WithHelp("ctrl+s", "skip the token vault"),
+	),
 	Num1: key.NewBinding(key.WithKeys("1")),
 	Num2: key.NewBinding(key.WithKeys("2")),
 	Num3: key.NewBinding(key.WithKeys("3")),
@@ -0,0 +1,13 @@
+package model
+
+// The resource types shown as tabs. Each package registers itself with the registry when
+// imported, so adding a tab only takes a new resource package and an import here.
+import (
+	_ "github.com/grammeaway/lazyhetzner/internal/resource/actions"
+	_ "github.com/grammeaway/lazyhetzner/internal/resource/firewall"
+	_ "github.com/grammeaway/lazyhetzner/internal/resource/floatingip"
+	_ "github.com/grammeaway/lazyhetzner/internal/resource/loadbalancer"
+	_ "github.com/grammeaway/lazyhetzner/internal/resource/network"
+	_ "github.com/grammeaway/lazyhetzner/internal/resource/server"
+	_ "github.com/grammeaway/lazyhetzner/internal/resource/volume"
+)
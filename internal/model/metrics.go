@@ -0,0 +1,144 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/grammeaway/lazyhetzner/internal/metrics"
+	"github.com/grammeaway/lazyhetzner/internal/registry"
+	"github.com/mattn/go-runewidth"
+)
+
+// How often the metrics of an open detail view are reloaded
+const metricsRefreshInterval = 30 * time.Second
+
+// Width of the series titles in front of the sparklines
+const metricsTitleWidth = 16
+
+// metricsTickMsg reloads the metrics of the detail view. Ticks of an earlier generation belong to a
+// view that was left or restarted, and are dropped.
+type metricsTickMsg struct {
+	generation int
+}
+
+// getMetricsTarget returns the resource of the detail view in view, if its kind has metrics
+func (m *Model) getMetricsTarget() (registry.MetricsKind, list.Item, bool) {
+	if m.State != stateDetailView || m.detail == nil || m.detail.Resource.ID == 0 {
+		return nil, nil, false
+	}
+	kind, exists := registry.Get(m.detail.Resource.ResourceType)
+	if !exists {
+		return nil, nil, false
+	}
+	metricsKind, ok := kind.(registry.MetricsKind)
+	if !ok {
+		return nil, nil, false
+	}
+	listItem, found := m.findListItem(m.detail.Resource.ResourceType, m.detail.Resource.ID)
+	if !found {
+		return nil, nil, false
+	}
+	return metricsKind, listItem, true
+}
+
+// hasMetrics reports whether the detail view in view charts metrics
+func (m Model) hasMetrics() bool {
+	_, _, ok := m.getMetricsTarget()
+	return ok
+}
+
+func (m Model) getMetricsWindow() metrics.Window {
+	return metrics.Windows[m.metricsWindow%len(metrics.Windows)]
+}
+
+// startMetrics loads the metrics of the detail view in view and keeps them refreshed while it stays open.
+// Views without metrics stop the refreshes of the previous one.
+func (m *Model) startMetrics() tea.Cmd {
+	m.metricsGeneration++
+	metricsKind, listItem, ok := m.getMetricsTarget()
+	if !ok {
+		return nil
+	}
+	if m.metrics != nil && (m.metrics.ResourceType != m.detail.Resource.ResourceType || m.metrics.ID != m.detail.Resource.ID) {
+		m.metrics = nil
+	}
+	return tea.Batch(m.loadMetrics(metricsKind, listItem), m.tickMetrics())
+}
+
+func (m *Model) loadMetrics(metricsKind registry.MetricsKind, listItem list.Item) tea.Cmd {
+	client := m.getClientForProject(getItemProject(listItem))
+	item, window := unwrapItem(listItem), m.getMetricsWindow()
+	return m.withRequestContext(func(ctx context.Context) tea.Cmd {
+		return metricsKind.LoadMetrics(ctx, client, item, window)
+	})
+}
+
+func (m *Model) tickMetrics() tea.Cmd {
+	generation := m.metricsGeneration
+	return tea.Tick(metricsRefreshInterval, func(time.Time) tea.Msg {
+		return metricsTickMsg{generation: generation}
+	})
+}
+
+func (m *Model) handleMetricsTick(msg metricsTickMsg) tea.Cmd {
+	if msg.generation != m.metricsGeneration {
+		return nil
+	}
+	metricsKind, listItem, ok := m.getMetricsTarget()
+	if !ok {
+		return nil
+	}
+	return tea.Batch(m.loadMetrics(metricsKind, listItem), m.tickMetrics())
+}
+
+// handleMetricsLoaded shows loaded metrics, unless they belong to a view or window that was left meanwhile
+func (m *Model) handleMetricsLoaded(msg metrics.LoadedMsg) {
+	if m.detail == nil || m.detail.Resource.ResourceType != msg.ResourceType || m.detail.Resource.ID != msg.ID || msg.Window != m.getMetricsWindow() {
+		return
+	}
+	m.metrics = &msg
+}
+
+// cycleMetricsWindow switches to the next time range and reloads the metrics
+func (m *Model) cycleMetricsWindow() tea.Cmd {
+	if !m.hasMetrics() {
+		return nil
+	}
+	m.metricsWindow = (m.metricsWindow + 1) % len(metrics.Windows)
+	return m.startMetrics()
+}
+
+// renderMetrics renders the metrics of the detail view as sparklines with a summary of each series
+func (m Model) renderMetrics(width int) string {
+	window := m.getMetricsWindow()
+	title := fmt.Sprintf("📈 Metrics (last %s, refreshed every %s)", window.Name, metricsRefreshInterval)
+
+	var lines []string
+	switch {
+	case m.metrics == nil:
+		lines = []string{"Loading metrics..."}
+	case m.metrics.Err != nil:
+		lines = []string{warningStyle.Render(fmt.Sprintf("⚠️  Loading metrics failed: %v", m.metrics.Err))}
+	case len(m.metrics.Series) == 0:
+		lines = []string{"No metrics available for this time range."}
+	default:
+		// Section padding and border take 4 characters, the summary up to 70
+		sparkWidth := max(10, min(metrics.Points, width-4-metricsTitleWidth-70))
+		for _, series := range m.metrics.Series {
+			summary := metrics.Summarize(series.Values)
+			lines = append(lines, fmt.Sprintf("%-*s %s  %s",
+				metricsTitleWidth,
+				series.Title,
+				lipgloss.NewStyle().Foreground(lipgloss.Color("#04B575")).Render(runewidth.FillRight(metrics.Sparkline(series.Values, sparkWidth), sparkWidth)),
+				helpStyle.Render(fmt.Sprintf("min %s • avg %s • max %s • now %s",
+					series.Unit.Format(summary.Min), series.Unit.Format(summary.Avg), series.Unit.Format(summary.Max), series.Unit.Format(summary.Last))),
+			))
+		}
+	}
+	return renderDetailSection(title, []string{strings.Join(lines, "\n")}, width)
+}
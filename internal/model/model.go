@@ -7,26 +7,23 @@ import (
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/grammeaway/lazyhetzner/internal/audit"
+	"github.com/grammeaway/lazyhetzner/internal/bulk"
+	"github.com/grammeaway/lazyhetzner/internal/cache"
 	"github.com/grammeaway/lazyhetzner/internal/config"
 	ctm "github.com/grammeaway/lazyhetzner/internal/context_menu"
-	ctm_fw "github.com/grammeaway/lazyhetzner/internal/context_menu/firewall"
-	ctm_fip "github.com/grammeaway/lazyhetzner/internal/context_menu/floatingip"
-	ctm_lb "github.com/grammeaway/lazyhetzner/internal/context_menu/loadbalancer"
-	ctm_n "github.com/grammeaway/lazyhetzner/internal/context_menu/network"
-	ctm_serv "github.com/grammeaway/lazyhetzner/internal/context_menu/server"
-	ctm_vol "github.com/grammeaway/lazyhetzner/internal/context_menu/volume"
+	"github.com/grammeaway/lazyhetzner/internal/cost"
 	"github.com/grammeaway/lazyhetzner/internal/input_form"
+	"github.com/grammeaway/lazyhetzner/internal/iplookup"
 	"github.com/grammeaway/lazyhetzner/internal/message"
+	"github.com/grammeaway/lazyhetzner/internal/metrics"
+	"github.com/grammeaway/lazyhetzner/internal/ratelimit"
+	"github.com/grammeaway/lazyhetzner/internal/registry"
 	"github.com/grammeaway/lazyhetzner/internal/resource"
-	r_fw "github.com/grammeaway/lazyhetzner/internal/resource/firewall"
-	r_fip "github.com/grammeaway/lazyhetzner/internal/resource/floatingip"
-	r_lb "github.com/grammeaway/lazyhetzner/internal/resource/loadbalancer"
-	r_n "github.com/grammeaway/lazyhetzner/internal/resource/network"
 	r_prj "github.com/grammeaway/lazyhetzner/internal/resource/project"
-	r_serv "github.com/grammeaway/lazyhetzner/internal/resource/server"
-	r_vol "github.com/grammeaway/lazyhetzner/internal/resource/volume"
+	"github.com/grammeaway/lazyhetzner/internal/secret"
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
-	"strconv"
+	"strings"
 	"time"
 )
 
@@ -53,42 +50,153 @@ type Model struct {
 	IsLoading                  bool
 	loadedLabels               map[string]string
 	labelsPertainingToResource string
-	loadbalancerBeingViewed    *hcloud.LoadBalancer
-	loadbalancerTargets        []hcloud.LoadBalancerTarget
-	loadbalancerServices       []hcloud.LoadBalancerService
-	firewallBeingViewed        *hcloud.Firewall
-	firewallRules              []hcloud.FirewallRule
-	networkBeingViewed         *hcloud.Network
-	networkSubnets             []hcloud.NetworkSubnet
-	serverBeingViewed          *hcloud.Server
-	serverDetailNetworks       []*hcloud.Network
+	detail                     *registry.Detail
+	detailScroll               int
+	detailCursor               int
+	backStack                  []page
+	forwardStack               []page
+	topologyCursor             int
+	topologyScroll             int
+	topologyCollapsed          map[string]bool
+	costEstimate               *cost.Estimate
+	costLabelKey               string
+	costScroll                 int
+	auditEntries               []audit.Entry
+	auditErr                   error
+	auditLoading               bool
+	auditFilter                audit.Filter
+	auditFilterInput           textinput.Model
+	auditScroll                int
+	metrics                    *metrics.LoadedMsg
+	metricsWindow              int
+	metricsGeneration          int
+	markedResources            map[resource.ResourceType]map[int64]bool
+	focusedColumns             map[resource.ResourceType]int
+	bulkMenu                   ctm.ContextMenu
+	bulkInput                  textinput.Model
+	bulkAction                 string
+	bulkTargets                []bulk.Target
+	bulkResults                *bulk.BulkActionCompletedMsg
+	pendingConfirmation        *confirmation
+	vault                      *secret.Vault
+	tokenErrors                map[string]error
+	passphraseInput            textinput.Model
+	resolvingTokens            bool
+	unlockErr                  error
+	confirmInput               textinput.Model
+	labelSelectors             map[resource.ResourceType]string
+	labelSelectorInput         textinput.Model
+	searchInput                textinput.Model
+	searchCursor               int
+	pendingSelection           *pendingSelection
+	ipLookupInput              textinput.Model
+	ipLookupResult             *iplookup.IPLookupResultMsg
+	ipLookupCursor             int
+	aggregated                 bool
+	projectClients             map[string]*hcloud.Client
+	projectLoadErrors          map[resource.ResourceType]map[string]error
+	searchAllProjects          bool
+	crossProjectItems          map[resource.ResourceType][]list.Item
+	crossProjectErrors         map[string]error
+	ipLookupAllProjects        bool
+	changedResources           map[resource.ResourceType]map[int64]changeKind
+	removedResources           map[resource.ResourceType][]string
+	changeGeneration           int
+	changeGenerations          map[resource.ResourceType]int
+	refreshGeneration          int
+	refreshGenerations         map[resource.ResourceType]int
+	resourceCache              *cache.Store[resource.ResourceType, []list.Item]
+	loadingResources           map[resource.ResourceType]*resourceLoad
+	loadSequence               int
+	sessionCtx                 context.Context
+	cancelSession              context.CancelFunc
+	serverIndex                *resource.ServerIndexStore
+	rateLimits                 map[string]*ratelimit.Tracker
 }
 
-// Resource types for tabs
+// getTabs returns the registered resource types in tab order
+func getTabs() []resource.ResourceType {
+	kinds := registry.Kinds()
+	tabs := make([]resource.ResourceType, len(kinds))
+	for i, kind := range kinds {
+		tabs[i] = kind.Type()
+	}
+	return tabs
+}
 
-var resourceTabs = []string{"Servers", "Networks", "Load Balancers", "Floating IPs", "Firewalls", "Volumes"}
+// getAdjacentTab returns the tab offset tabs away from the active one, wrapping around if wrap is set
+func (m *Model) getAdjacentTab(offset int, wrap bool) (resource.ResourceType, bool) {
+	tabs := getTabs()
+	for i, rt := range tabs {
+		if rt != m.activeTab {
+			continue
+		}
+		next := i + offset
+		if wrap {
+			next = (next%len(tabs) + len(tabs)) % len(tabs)
+		}
+		if next < 0 || next >= len(tabs) {
+			return rt, false
+		}
+		return tabs[next], true
+	}
+	return m.activeTab, false
+}
 
-func (m *Model) getResourceLoadCmd(rt resource.ResourceType) tea.Cmd {
+func (m *Model) getResourceLoadCmd(ctx context.Context, rt resource.ResourceType) tea.Cmd {
+	if m.aggregated {
+		return m.getAggregatedLoadCmd(ctx, rt)
+	}
 	if m.client == nil {
 		return nil
 	}
+	return getClientLoadCmd(ctx, m.client, rt, m.labelSelectors[rt], m.getServerIndex())
+}
 
-	switch rt {
-	case resource.ResourceServers:
-		return r_serv.LoadServers(m.client)
-	case resource.ResourceNetworks:
-		return r_n.LoadNetworks(m.client)
-	case resource.ResourceLoadBalancers:
-		return r_lb.LoadLoadBalancers(m.client)
-	case resource.ResourceFloatingIPs:
-		return r_fip.LoadFloatingIPs(m.client)
-	case resource.ResourceFirewalls:
-		return r_fw.LoadFirewalls(m.client)
-	case resource.ResourceVolumes:
-		return r_vol.LoadVolumes(m.client)
-	default:
+func getClientLoadCmd(ctx context.Context, client *hcloud.Client, rt resource.ResourceType, labelSelector string, serverIndex *resource.ServerIndexStore) tea.Cmd {
+	kind, exists := registry.Get(rt)
+	if !exists {
 		return nil
 	}
+	return kind.Load(ctx, client, labelSelector, serverIndex)
+}
+
+// getTabTitle returns the tab name, including the active label selector if one is set
+func (m *Model) getTabTitle(rt resource.ResourceType) string {
+	title := resource.GetResourceNameFromType(rt)
+	if selector := m.labelSelectors[rt]; selector != "" {
+		title = fmt.Sprintf("%s [%s]", title, selector)
+	}
+	return title
+}
+
+func (m *Model) openLabelSelectorInput() tea.Cmd {
+	m.labelSelectorInput = textinput.New()
+	m.labelSelectorInput.Placeholder = "e.g. env=prod,role in (web,api),!deprecated"
+	m.labelSelectorInput.CharLimit = 256
+	m.labelSelectorInput.Width = min(70, m.width-10)
+	m.labelSelectorInput.SetValue(m.labelSelectors[m.activeTab])
+	m.labelSelectorInput.Focus()
+	m.State = stateLabelSelectorInput
+	return textinput.Blink
+}
+
+// setLabelSelector applies a label selector to the active tab and reloads it; an empty selector clears the filter
+func (m *Model) setLabelSelector(selector string) tea.Cmd {
+	if m.labelSelectors == nil {
+		m.labelSelectors = make(map[resource.ResourceType]string)
+	}
+	if selector == "" {
+		delete(m.labelSelectors, m.activeTab)
+	} else {
+		m.labelSelectors[m.activeTab] = selector
+	}
+	m.State = stateResourceView
+	m.LoadedResources[m.activeTab] = false
+	// The selector changes which resources are shown, so start with a fresh list instead of highlighting the difference
+	delete(m.Lists, m.activeTab)
+	m.getResourceCache().Invalidate(m.activeTab)
+	return m.loadResource(m.activeTab)
 }
 
 func clearStatusMessage() tea.Cmd {
@@ -97,106 +205,99 @@ func clearStatusMessage() tea.Cmd {
 	})
 }
 
-func (m *Model) executeContextAction(selectedAction string, resourceType resource.ResourceType, resourceID int64) tea.Cmd {
-	switch resourceType {
-	case resource.ResourceServers:
-		if selectedAction == "view_details" {
-			return r_serv.LoadServerDetails(m.client, resourceID)
-		}
-		server, _, err := m.client.Server.Get(context.Background(), strconv.FormatInt(resourceID, 10))
-		if err != nil {
-			return func() tea.Msg {
-				return message.ErrorMsg{err}
-			}
-		}
-		if server == nil {
-			return func() tea.Msg {
-				return message.ErrorMsg{fmt.Errorf("server with ID %d not found", resourceID)}
-			}
-		}
+// findLoadedItem returns the loaded list item of a resource, unwrapped from its project in the all-projects view
+func (m *Model) findLoadedItem(resourceType resource.ResourceType, resourceID int64) (list.Item, bool) {
+	item, found := m.findListItem(resourceType, resourceID)
+	return unwrapItem(item), found
+}
 
-		return ctm_serv.ExecuteServerContextAction(selectedAction, server, m.config.DefaultTerminal)
-	case resource.ResourceNetworks:
-		network, _, err := m.client.Network.Get(context.Background(), strconv.FormatInt(resourceID, 10))
-		if err != nil {
-			return func() tea.Msg {
-				return message.ErrorMsg{err}
-			}
-		}
-		if network == nil {
-			return func() tea.Msg {
-				return message.ErrorMsg{fmt.Errorf("network with ID %d not found", resourceID)}
-			}
-		}
-		return ctm_n.ExecuteNetworkContextAction(selectedAction, network)
-	case resource.ResourceLoadBalancers:
-		loadBalancer, _, err := m.client.LoadBalancer.Get(context.Background(), strconv.FormatInt(resourceID, 10))
-		if err != nil {
-			return func() tea.Msg {
-				return message.ErrorMsg{err}
-			}
-		}
-		if loadBalancer == nil {
-			return func() tea.Msg {
-				return message.ErrorMsg{fmt.Errorf("load balancer with ID %d not found", resourceID)}
-			}
-		}
-		return ctm_lb.ExecuteLoadbalancerContextAction(selectedAction, loadBalancer)
-	case resource.ResourceVolumes:
-		volume, _, err := m.client.Volume.Get(context.Background(), strconv.FormatInt(resourceID, 10))
-		if err != nil {
-			return func() tea.Msg {
-				return message.ErrorMsg{err}
-			}
-		}
-		if volume == nil {
-			return func() tea.Msg {
-				return message.ErrorMsg{fmt.Errorf("volume with ID %d not found", resourceID)}
-			}
-		}
-		// unfold server details if attached
-		if volume.Server != nil {
-			server, _, err := m.client.Server.GetByID(context.Background(), volume.Server.ID)
-			if err == nil && server != nil {
-				volume.Server = server
-			}
-		}
-		return ctm_vol.ExecuteVolumeContextAction(selectedAction, volume)
-	case resource.ResourceFirewalls:
-		firewall, _, err := m.client.Firewall.GetByID(context.Background(), resourceID)
-		if err != nil {
-			return func() tea.Msg {
-				return message.ErrorMsg{err}
-			}
-		}
-		if firewall == nil {
-			return func() tea.Msg {
-				return message.ErrorMsg{fmt.Errorf("firewall with ID %d not found", resourceID)}
-			}
-		}
-		return ctm_fw.ExecuteFirewallContextAction(selectedAction, firewall)
-	case resource.ResourceFloatingIPs:
-		floatingIP, _, err := m.client.FloatingIP.Get(context.Background(), strconv.FormatInt(resourceID, 10))
-		if err != nil {
-			return func() tea.Msg {
-				return message.ErrorMsg{err}
-			}
+// findListItem returns a resource's item as stored in its tab's list
+func (m *Model) findListItem(resourceType resource.ResourceType, resourceID int64) (list.Item, bool) {
+	currentList, exists := m.Lists[resourceType]
+	if !exists {
+		return nil, false
+	}
+	for _, item := range currentList.Items() {
+		if id, ok := getItemResourceID(item); ok && id == resourceID {
+			return item, true
 		}
-		if floatingIP == nil {
-			return func() tea.Msg {
-				return message.ErrorMsg{fmt.Errorf("floating IP with ID %d not found", resourceID)}
-			}
+	}
+	return nil, false
+}
+
+// getSelectedContextMenu returns the context menu for the selected resource of the active tab
+func (m *Model) getSelectedContextMenu() (ctm.ContextMenu, bool) {
+	kind, exists := registry.Get(m.activeTab)
+	if !exists {
+		return ctm.ContextMenu{}, false
+	}
+	currentList, exists := m.Lists[m.activeTab]
+	if !exists || currentList.SelectedItem() == nil {
+		return ctm.ContextMenu{}, false
+	}
+	selectedItem := currentList.SelectedItem()
+	menu, ok := kind.ContextMenu(unwrapItem(selectedItem))
+	menu.Project = getItemProject(selectedItem)
+	if m.isReadOnly(menu.Project) {
+		menu.Items = ctm.WithoutMutating(menu.Items)
+	}
+	return menu, ok
+}
+
+// openSelectedDetails shows the details of the selected resource
+func (m *Model) openSelectedDetails() tea.Cmd {
+	menu, ok := m.getSelectedContextMenu()
+	if !ok {
+		return nil
+	}
+	for _, item := range menu.Items {
+		if item.Action == "view_details" {
+			m.contextMenu = menu
+			return m.executeContextAction(item.Action, menu.ResourceType, menu.ResourceID)
 		}
-		if floatingIP.Server != nil {
-			server, _, err := m.client.Server.GetByID(context.Background(), floatingIP.Server.ID)
-			if err == nil && server != nil {
-				floatingIP.Server = server
-			}
+	}
+	// Without a details action, the preview is shown full screen
+	if detail, ok := m.getSelectedPreview(); ok {
+		return m.showDetail(detail)
+	}
+	return nil
+}
+
+// executeContextAction runs a context menu action on the already loaded resource, without fetching it again
+func (m *Model) executeContextAction(selectedAction string, resourceType resource.ResourceType, resourceID int64) tea.Cmd {
+	client := m.getClientForProject(m.contextMenu.Project)
+	item, found := m.findLoadedItem(resourceType, resourceID)
+	if !found {
+		return func() tea.Msg {
+			return message.ErrorMsg{Err: fmt.Errorf("%s with ID %d not found", strings.ToLower(resource.GetResourceNameFromType(resourceType)), resourceID)}
 		}
-		return ctm_fip.ExecuteFloatingIPContextAction(selectedAction, floatingIP)
 	}
 
-	return nil
+	kind, exists := registry.Get(resourceType)
+	if !exists {
+		return nil
+	}
+	env := registry.ActionEnv{Client: client, DefaultTerminal: m.config.DefaultTerminal}
+	execute := func(m *Model) tea.Cmd {
+		return m.withRequestContext(func(ctx context.Context) tea.Cmd {
+			return kind.ExecuteAction(ctx, selectedAction, item, env)
+		})
+	}
+	// Menus of read-only projects hide mutating items, but actions may also be run without the menu
+	menu, _ := kind.ContextMenu(item)
+	menuItem, found := ctm.FindItem(menu.Items, selectedAction)
+	if !found {
+		return execute(m)
+	}
+	project := m.getProjectName(m.contextMenu.Project)
+	if menuItem.Mutating && m.isReadOnly(project) {
+		return m.refuseReadOnly(menuItem.Label)
+	}
+	var protected []string
+	if m.isProtected(project) {
+		protected = []string{project}
+	}
+	return m.confirmDestructive(menuItem, protected, 1, execute)
 }
 
 func (m Model) Init() tea.Cmd {
@@ -224,3 +325,23 @@ func (m *Model) setDefaultProject(name string) {
 func (m *Model) setDefaultTerminal(terminal string) {
 	m.config.DefaultTerminal = terminal
 }
+
+// getResourceItems converts a resource loaded message into list items for its tab
+func getResourceItems(msg tea.Msg) (resource.ResourceType, []list.Item, bool) {
+	for _, kind := range registry.Kinds() {
+		if items, ok := kind.Items(msg); ok {
+			return kind.Type(), items, true
+		}
+	}
+	return 0, nil, false
+}
+
+// getDetail converts a message asking for a detail view into its content
+func getDetail(msg tea.Msg) (registry.Detail, bool) {
+	for _, kind := range registry.Kinds() {
+		if detail, ok := kind.Detail(msg); ok {
+			return detail, true
+		}
+	}
+	return registry.Detail{}, false
+}
@@ -0,0 +1,265 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/grammeaway/lazyhetzner/internal/registry"
+	"github.com/grammeaway/lazyhetzner/internal/resource"
+)
+
+// page is a view on the navigation stack, with everything needed to show it again as it was left
+type page struct {
+	State state
+	Tab   resource.ResourceType
+	// Detail belongs to detail views. Scroll is the first shown line, Cursor the selected link of
+	// a detail view or the selected node of the topology view.
+	Detail *registry.Detail
+	Scroll int
+	Cursor int
+	// Labels and LabelsOf belong to label views
+	Labels   map[string]string
+	LabelsOf string
+}
+
+// detailLink is a link of a detail view, with the position of the line it belongs to
+type detailLink struct {
+	registry.Link
+	Section int
+	Line    int
+}
+
+// currentPage captures the view being shown. Menus and inputs opened on top of
+// the resource list aren't pages of their own, so they count as the resource view.
+func (m Model) currentPage() page {
+	current := page{State: stateResourceView, Tab: m.activeTab}
+	switch m.State {
+	case stateDetailView:
+		current.State = m.State
+		current.Detail, current.Scroll, current.Cursor = m.detail, m.detailScroll, m.detailCursor
+	case stateLabelView:
+		current.State = m.State
+		current.Labels, current.LabelsOf = m.loadedLabels, m.labelsPertainingToResource
+	case stateTopologyView:
+		current.State = m.State
+		current.Scroll, current.Cursor = m.topologyScroll, m.topologyCursor
+	case stateCostView:
+		current.State = m.State
+		current.Scroll = m.costScroll
+	case stateAuditView:
+		current.State = m.State
+		current.Scroll = m.auditScroll
+	}
+	return current
+}
+
+func (m *Model) showPage(p page) {
+	m.State = p.State
+	m.activeTab = p.Tab
+	m.detail, m.detailScroll, m.detailCursor = p.Detail, p.Scroll, p.Cursor
+	m.loadedLabels, m.labelsPertainingToResource = p.Labels, p.LabelsOf
+	switch p.State {
+	case stateTopologyView:
+		m.topologyScroll, m.topologyCursor = p.Scroll, p.Cursor
+	case stateCostView:
+		m.costScroll = p.Scroll
+	case stateAuditView:
+		m.auditScroll = p.Scroll
+	}
+}
+
+// navigate shows a new page, remembering the current one to go back to. The returned command
+// starts the live updates of the page, if it has any.
+func (m *Model) navigate(next page) tea.Cmd {
+	m.backStack = append(m.backStack, m.currentPage())
+	m.forwardStack = nil
+	m.showPage(next)
+	return m.startMetrics()
+}
+
+func (m *Model) showDetail(detail registry.Detail) tea.Cmd {
+	return m.navigate(page{State: stateDetailView, Tab: m.activeTab, Detail: &detail})
+}
+
+// goBack returns to the previous page. Without one, sub-views return to the resource list.
+func (m *Model) goBack() tea.Cmd {
+	if len(m.backStack) == 0 {
+		if m.State != stateResourceView {
+			m.forwardStack = append(m.forwardStack, m.currentPage())
+			m.showPage(page{State: stateResourceView, Tab: m.activeTab})
+		}
+		return m.startMetrics()
+	}
+	m.forwardStack = append(m.forwardStack, m.currentPage())
+	previous := m.backStack[len(m.backStack)-1]
+	m.backStack = m.backStack[:len(m.backStack)-1]
+	m.showPage(previous)
+	return m.startMetrics()
+}
+
+// goForward shows the page that was left with goBack
+func (m *Model) goForward() tea.Cmd {
+	if len(m.forwardStack) == 0 {
+		return nil
+	}
+	m.backStack = append(m.backStack, m.currentPage())
+	next := m.forwardStack[len(m.forwardStack)-1]
+	m.forwardStack = m.forwardStack[:len(m.forwardStack)-1]
+	m.showPage(next)
+	return m.startMetrics()
+}
+
+func (m *Model) clearNavigation() {
+	m.backStack = nil
+	m.forwardStack = nil
+}
+
+func getPageTitle(p page) string {
+	switch p.State {
+	case stateDetailView:
+		if p.Detail == nil {
+			return "Details"
+		}
+		if p.Detail.Name != "" {
+			return p.Detail.Name
+		}
+		return p.Detail.Title
+	case stateLabelView:
+		return "Labels of " + p.LabelsOf
+	case stateTopologyView:
+		return "Topology"
+	case stateCostView:
+		return "Cost Estimate"
+	case stateAuditView:
+		return "Audit Log"
+	default:
+		return resource.GetResourceNameFromType(p.Tab)
+	}
+}
+
+// renderBreadcrumbs renders the path of pages that led to the current one
+func (m Model) renderBreadcrumbs() string {
+	crumbs := make([]string, 0, len(m.backStack)+1)
+	for _, previous := range m.backStack {
+		crumbs = append(crumbs, getPageTitle(previous))
+	}
+	crumbs = append(crumbs, titleStyle.Render(getPageTitle(m.currentPage())))
+	breadcrumbs := "🧭 " + strings.Join(crumbs, " › ")
+	if len(m.forwardStack) > 0 {
+		breadcrumbs += helpStyle.Render(" • ] forward to " + getPageTitle(m.forwardStack[len(m.forwardStack)-1]))
+	}
+	return breadcrumbs
+}
+
+// getDetailLinks returns the links of a detail view in the order they are selected
+func getDetailLinks(detail *registry.Detail) []detailLink {
+	if detail == nil {
+		return nil
+	}
+	links := []detailLink{}
+	for sectionIndex, section := range detail.Sections {
+		lines := make([]int, 0, len(section.Links))
+		for line := range section.Links {
+			if line < len(section.Lines) {
+				lines = append(lines, line)
+			}
+		}
+		sort.Ints(lines)
+		for _, line := range lines {
+			links = append(links, detailLink{Link: section.Links[line], Section: sectionIndex, Line: line})
+		}
+	}
+	return links
+}
+
+// moveDetailCursor selects the previous or next link of the detail view, scrolling if there are no links
+func (m *Model) moveDetailCursor(offset int) {
+	links := getDetailLinks(m.detail)
+	if len(links) == 0 {
+		m.scrollDetail(offset)
+		return
+	}
+	m.detailCursor = max(0, min(len(links)-1, m.detailCursor+offset))
+	m.scrollToDetailCursor()
+}
+
+func (m *Model) scrollDetail(offset int) {
+	maxScroll := max(0, len(m.renderDetailBody())-m.getDetailBodyHeight())
+	m.detailScroll = max(0, min(maxScroll, m.detailScroll+offset))
+}
+
+// scrollToDetailCursor scrolls the detail view just enough to show the selected link
+func (m *Model) scrollToDetailCursor() {
+	for i, line := range m.renderDetailBody() {
+		if !strings.Contains(line, selectedLinkMarker) {
+			continue
+		}
+		if i < m.detailScroll {
+			m.detailScroll = i
+		} else if visible := m.getDetailBodyHeight(); i >= m.detailScroll+visible {
+			m.detailScroll = i - visible + 1
+		}
+		return
+	}
+}
+
+// openDetailLink opens the resource behind the selected link of the detail view
+func (m *Model) openDetailLink() tea.Cmd {
+	links := getDetailLinks(m.detail)
+	if m.detailCursor >= len(links) {
+		return nil
+	}
+	return m.openLink(links[m.detailCursor].Link)
+}
+
+// openLink shows the view of a loaded resource, or of a part of it
+func (m *Model) openLink(link registry.Link) tea.Cmd {
+	kind, exists := registry.Get(link.ResourceType)
+	listItem, found := m.findListItem(link.ResourceType, link.ID)
+	if !exists || !found {
+		m.statusMessage = fmt.Sprintf("⚠️  %s with ID %d isn't loaded", resource.GetResourceNameFromType(link.ResourceType), link.ID)
+		return clearStatusMessage()
+	}
+	item := unwrapItem(listItem)
+
+	if link.Part != "" {
+		if partKind, ok := kind.(registry.PartKind); ok {
+			if detail, ok := partKind.PartDetail(item, link.Part, m.getRelatedItems); ok {
+				return m.showDetail(detail)
+			}
+		}
+		m.statusMessage = fmt.Sprintf("⚠️  %s is no longer part of %s %d", link.Part, strings.ToLower(resource.GetResourceNameFromType(link.ResourceType)), link.ID)
+		return clearStatusMessage()
+	}
+
+	// Resources with a details action load their full details, the others show their preview
+	if menu, ok := kind.ContextMenu(item); ok {
+		for _, menuItem := range menu.Items {
+			if menuItem.Action == "view_details" {
+				menu.Project = getItemProject(listItem)
+				m.contextMenu = menu
+				return m.executeContextAction(menuItem.Action, link.ResourceType, link.ID)
+			}
+		}
+	}
+	if detail, ok := kind.Preview(item); ok {
+		return m.showDetail(detail)
+	}
+	return nil
+}
+
+// getRelatedItems returns the loaded items of a resource type, for views that show related resources
+func (m *Model) getRelatedItems(rt resource.ResourceType) []list.Item {
+	currentList, exists := m.Lists[rt]
+	if !exists {
+		return nil
+	}
+	items := make([]list.Item, 0, len(currentList.Items()))
+	for _, item := range currentList.Items() {
+		items = append(items, unwrapItem(item))
+	}
+	return items
+}
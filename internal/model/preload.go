@@ -0,0 +1,159 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/grammeaway/lazyhetzner/internal/cache"
+	"github.com/grammeaway/lazyhetzner/internal/message"
+	"github.com/grammeaway/lazyhetzner/internal/resource"
+)
+
+// Cached resources older than this are shown as stale
+const staleAfter = 2 * time.Minute
+
+func (m *Model) getResourceCache() *cache.Store[resource.ResourceType, []list.Item] {
+	if m.resourceCache == nil {
+		m.resourceCache = cache.NewStore[resource.ResourceType, []list.Item]()
+	}
+	return m.resourceCache
+}
+
+// getServerIndex returns the store sharing server listings between the loaders of a project
+func (m *Model) getServerIndex() *resource.ServerIndexStore {
+	if m.serverIndex == nil {
+		m.serverIndex = resource.NewServerIndexStore()
+	}
+	return m.serverIndex
+}
+
+// loadResource loads a tab unless a load for it is already in flight.
+// The load is bounded by the request timeout and cancelled when the project is left.
+func (m *Model) loadResource(rt resource.ResourceType) tea.Cmd {
+	if m.isLoadingResource(rt) {
+		return nil
+	}
+	ctx, cancel := m.requestContext()
+	loadCmd := m.getResourceLoadCmd(ctx, rt)
+	if loadCmd == nil {
+		cancel()
+		return nil
+	}
+	if m.loadingResources == nil {
+		m.loadingResources = make(map[resource.ResourceType]*resourceLoad)
+	}
+	m.loadSequence++
+	loadID := m.loadSequence
+	m.loadingResources[rt] = &resourceLoad{ID: loadID, Cancel: cancel}
+
+	return tea.Batch(
+		resource.StartResourceLoad(rt),
+		func() tea.Msg {
+			defer cancel()
+			return resourceLoadedMsg{ResourceType: rt, LoadID: loadID, Msg: loadCmd()}
+		},
+	)
+}
+
+func (m *Model) isLoadingResource(rt resource.ResourceType) bool {
+	return m.loadingResources[rt] != nil
+}
+
+// handleResourceLoaded shows the result of a tab load. Results that no longer match the
+// tab's in-flight load were cancelled or belong to a previous project, and are dropped.
+func (m *Model) handleResourceLoaded(msg resourceLoadedMsg) tea.Cmd {
+	rt := msg.ResourceType
+	if load := m.loadingResources[rt]; load == nil || load.ID != msg.LoadID {
+		return nil
+	}
+	delete(m.loadingResources, rt)
+	if m.loadingResource == rt {
+		m.IsLoading = false
+	}
+
+	switch loaded := msg.Msg.(type) {
+	case message.ErrorMsg:
+		return m.handleResourceLoadFailed(rt, loaded.Err)
+	case aggregatedResourcesLoadedMsg:
+		if m.projectLoadErrors == nil {
+			m.projectLoadErrors = make(map[resource.ResourceType]map[string]error)
+		}
+		m.projectLoadErrors[rt] = loaded.Errors
+		return m.cacheResourceItems(rt, loaded.Items)
+	default:
+		if _, items, ok := getResourceItems(loaded); ok {
+			return m.cacheResourceItems(rt, items)
+		}
+		return nil
+	}
+}
+
+// preloadResources loads every tab at the same time, so switching tabs doesn't wait for the API
+func (m *Model) preloadResources() tea.Cmd {
+	cmds := make([]tea.Cmd, 0, len(getTabs()))
+	for _, rt := range getTabs() {
+		cmds = append(cmds, m.loadResource(rt))
+	}
+	return tea.Batch(cmds...)
+}
+
+// showTab switches to a tab, served from the cache if possible. Stale tabs are refreshed in the background.
+func (m *Model) showTab(rt resource.ResourceType) tea.Cmd {
+	// A background refresh of the tab being left isn't needed anymore, as it still shows its cached items
+	if previous := m.activeTab; previous != rt && m.LoadedResources[previous] {
+		m.cancelResourceLoad(previous)
+	}
+	m.activeTab = rt
+	if !m.LoadedResources[rt] {
+		return m.loadResource(rt)
+	}
+	if m.isStale(rt) {
+		return m.loadResource(rt)
+	}
+	return nil
+}
+
+// cacheResourceItems stores freshly loaded items and shows them in their tab
+func (m *Model) cacheResourceItems(rt resource.ResourceType, items []list.Item) tea.Cmd {
+	m.LoadedResources[rt] = true
+	m.getResourceCache().Set(rt, items)
+	return m.setResourceItems(rt, items)
+}
+
+// handleResourceLoadFailed shows the error screen when the tab in view could not be loaded at all.
+// Failures of background loads only show up in the status bar, so the current view stays usable.
+func (m *Model) handleResourceLoadFailed(rt resource.ResourceType, err error) tea.Cmd {
+	if errors.Is(err, context.Canceled) {
+		return nil
+	}
+	err = describeRequestError(err, m.config.GetRequestTimeout())
+	if rt == m.activeTab && !m.LoadedResources[rt] {
+		m.State = stateError
+		m.err = err
+		return nil
+	}
+	m.statusMessage = fmt.Sprintf("⚠️  Loading %s failed: %v", resource.GetResourceNameFromType(rt), err)
+	return clearStatusMessage()
+}
+
+// isStale reports whether the cached resources of a tab are older than they should be
+func (m *Model) isStale(rt resource.ResourceType) bool {
+	entry, ok := m.getResourceCache().Get(rt)
+	if !ok {
+		return false
+	}
+	return entry.Age() > max(staleAfter, 2*m.getAutoRefreshInterval(rt))
+}
+
+// getStaleLabel describes how old the active tab's resources are, if they are stale
+func (m *Model) getStaleLabel() string {
+	if !m.isStale(m.activeTab) {
+		return ""
+	}
+	entry, _ := m.getResourceCache().Get(m.activeTab)
+	return fmt.Sprintf("⏱ stale since %s", entry.LoadedAt.Format("15:04:05"))
+}
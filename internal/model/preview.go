@@ -0,0 +1,96 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/grammeaway/lazyhetzner/internal/config"
+	"github.com/grammeaway/lazyhetzner/internal/registry"
+)
+
+// The preview pane is only shown if the terminal is at least this wide
+const minPreviewTerminalWidth = 100
+
+// getPreviewWidth returns the width of the preview pane, or 0 if it isn't shown
+func (m Model) getPreviewWidth() int {
+	if (m.config != nil && m.config.HidePreview) || m.width < minPreviewTerminalWidth {
+		return 0
+	}
+	return max(36, min(80, (m.width-4)*2/5))
+}
+
+// getListWidth returns the width of the resource lists, which share the screen with the preview pane
+func (m Model) getListWidth() int {
+	if previewWidth := m.getPreviewWidth(); previewWidth > 0 {
+		return m.width - 4 - previewWidth - 1
+	}
+	return m.width - 4
+}
+
+func (m *Model) resizeLists() {
+	for rt, resourceList := range m.Lists {
+		resourceList.SetWidth(m.getListWidth())
+		resourceList.SetHeight(m.height - 10)
+		m.Lists[rt] = resourceList
+	}
+}
+
+func (m *Model) togglePreview() tea.Cmd {
+	if m.config == nil {
+		return nil
+	}
+	m.config.HidePreview = !m.config.HidePreview
+	m.resizeLists()
+	return config.SaveConfigCmd(m.config)
+}
+
+// getSelectedPreview returns the preview of the resource selected in the active tab
+func (m Model) getSelectedPreview() (registry.Detail, bool) {
+	kind, exists := registry.Get(m.activeTab)
+	if !exists {
+		return registry.Detail{}, false
+	}
+	currentList, exists := m.Lists[m.activeTab]
+	if !exists || currentList.SelectedItem() == nil {
+		return registry.Detail{}, false
+	}
+	selectedItem := currentList.SelectedItem()
+	detail, ok := kind.Preview(unwrapItem(selectedItem))
+	if project := getItemProject(selectedItem); ok && project != "" {
+		detail.Header = fmt.Sprintf("[%s] %s", project, detail.Header)
+	}
+	return detail, ok
+}
+
+// renderPreview renders the preview pane next to the resource list, cut off at the list's height
+func (m Model) renderPreview(height int) string {
+	width := m.getPreviewWidth()
+	if width == 0 {
+		return ""
+	}
+	var preview strings.Builder
+	detail, ok := m.getSelectedPreview()
+	if !ok {
+		preview.WriteString(helpStyle.Render("Nothing selected."))
+	} else {
+		preview.WriteString(detailTitleStyle.Render(detail.Header) + "\n\n")
+		columns, columnWidth, gap := detailGridLayout(width)
+		sections := make([]string, 0, len(detail.Sections))
+		for _, section := range detail.Sections {
+			sections = append(sections, renderDetailSection(section.Title, section.Lines, columnWidth))
+		}
+		preview.WriteString(renderDetailGrid(sections, columns, gap))
+	}
+	return lipgloss.NewStyle().Width(width).MaxWidth(width).MaxHeight(height).Render(preview.String())
+}
+
+// renderWithPreview shows a rendered resource list next to the preview pane
+func (m Model) renderWithPreview(listView string) string {
+	preview := m.renderPreview(lipgloss.Height(listView))
+	if preview == "" {
+		return listView
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, lipgloss.NewStyle().Width(m.getListWidth()).Render(listView), " ", preview)
+}
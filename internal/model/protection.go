@@ -0,0 +1,160 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/grammeaway/lazyhetzner/internal/bulk"
+	ctm "github.com/grammeaway/lazyhetzner/internal/context_menu"
+)
+
+// confirmation is a destructive operation on protected projects, run once their names are typed
+type confirmation struct {
+	prompt   string
+	expected string
+	run      func(m *Model) tea.Cmd
+}
+
+// getProjectName returns the project a resource belongs to, which is empty for resources of the current project
+func (m Model) getProjectName(project string) string {
+	if project == "" {
+		return m.currentProject
+	}
+	return project
+}
+
+func (m Model) isReadOnly(project string) bool {
+	if m.config == nil {
+		return false
+	}
+	projectConfig := m.config.GetProject(m.getProjectName(project))
+	return projectConfig != nil && projectConfig.ReadOnly
+}
+
+func (m Model) isProtected(project string) bool {
+	if m.config == nil {
+		return false
+	}
+	projectConfig := m.config.GetProject(m.getProjectName(project))
+	return projectConfig != nil && projectConfig.IsProtected()
+}
+
+// getShownProtectedProjects returns the protected projects whose resources are shown
+func (m Model) getShownProtectedProjects() []string {
+	if !m.aggregated {
+		if m.currentProject != "" && m.isProtected(m.currentProject) {
+			return []string{m.currentProject}
+		}
+		return nil
+	}
+	var projects []string
+	for project := range m.projectClients {
+		if m.isProtected(project) {
+			projects = append(projects, project)
+		}
+	}
+	slices.Sort(projects)
+	return projects
+}
+
+// renderProtectedBanner renders the banner shown on top of every view of a protected project
+func (m Model) renderProtectedBanner() string {
+	switch m.State {
+	case StateProjectSelect, stateProjectManage, stateTerminalConfig, stateTokenInput, stateTokenUnlock:
+		return ""
+	}
+	projects := m.getShownProtectedProjects()
+	if len(projects) == 0 {
+		return ""
+	}
+	return protectedBannerStyle.Render("⚠️  PRODUCTION: " + strings.Join(projects, ", "))
+}
+
+// hasReadOnlyTarget reports whether any of the bulk targets belongs to a read-only project
+func (m Model) hasReadOnlyTarget(targets []bulk.Target) bool {
+	for _, target := range targets {
+		if m.isReadOnly(target.Project) {
+			return true
+		}
+	}
+	return false
+}
+
+// getProtectedTargetProjects returns the protected projects the bulk targets belong to
+func (m Model) getProtectedTargetProjects(targets []bulk.Target) []string {
+	var projects []string
+	for _, target := range targets {
+		if m.isProtected(target.Project) && !slices.Contains(projects, target.Project) {
+			projects = append(projects, target.Project)
+		}
+	}
+	slices.Sort(projects)
+	return projects
+}
+
+// refuseReadOnly tells that an action was refused, as it would change resources of a read-only project
+func (m *Model) refuseReadOnly(label string) tea.Cmd {
+	m.statusMessage = fmt.Sprintf("🔒 %s is not available in read-only projects", strings.TrimSpace(label))
+	return clearStatusMessage()
+}
+
+// confirmDestructive runs a destructive operation, asking for the names of the protected projects it affects first
+func (m *Model) confirmDestructive(item ctm.ContextMenuItem, projects []string, count int, run func(m *Model) tea.Cmd) tea.Cmd {
+	if !item.Destructive || len(projects) == 0 {
+		return run(m)
+	}
+	expected := strings.Join(projects, ", ")
+	prompt := fmt.Sprintf("%s is protected. Type its name to confirm '%s' on %d resource(s):", projects[0], strings.TrimSpace(item.Label), count)
+	if len(projects) > 1 {
+		prompt = fmt.Sprintf("%s are protected. Type their names as '%s' to confirm '%s' on %d resource(s):", expected, expected, strings.TrimSpace(item.Label), count)
+	}
+	m.pendingConfirmation = &confirmation{prompt: prompt, expected: expected, run: run}
+	m.confirmInput = textinput.New()
+	m.confirmInput.Placeholder = "project name"
+	m.confirmInput.CharLimit = 256
+	m.confirmInput.Width = min(70, m.width-10)
+	m.confirmInput.Focus()
+	m.State = stateConfirmInput
+	return textinput.Blink
+}
+
+func (m *Model) submitConfirmation() tea.Cmd {
+	if m.pendingConfirmation == nil {
+		m.State = stateResourceView
+		return nil
+	}
+	if strings.TrimSpace(m.confirmInput.Value()) != m.pendingConfirmation.expected {
+		m.statusMessage = "⚠️  The project name doesn't match"
+		return clearStatusMessage()
+	}
+	pending := m.pendingConfirmation
+	m.cancelConfirmation()
+	return pending.run(m)
+}
+
+func (m *Model) cancelConfirmation() {
+	m.pendingConfirmation = nil
+	m.confirmInput.Blur()
+	m.State = stateResourceView
+}
+
+func (m Model) renderConfirmInput() string {
+	if m.pendingConfirmation == nil {
+		return ""
+	}
+	statusView := ""
+	if m.statusMessage != "" {
+		statusView = "\n\n" + warningStyle.Render(m.statusMessage)
+	}
+	return fmt.Sprintf(
+		"\n%s\n\n%s\n\n%s%s\n\n%s\n",
+		titleStyle.Render("lazyhetzner - Confirm on Protected Project"),
+		errorStyle.Render(m.pendingConfirmation.prompt),
+		m.confirmInput.View(),
+		statusView,
+		helpStyle.Render("Enter: confirm • Esc: cancel"),
+	)
+}
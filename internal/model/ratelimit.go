@@ -0,0 +1,82 @@
+package model
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/grammeaway/lazyhetzner/internal/ratelimit"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+// newClient creates an API client for a project whose responses feed the project's rate limit tracker,
+// which also decides how long the client's own retries wait. The one-time access token is tracked under an empty project name.
+func (m *Model) newClient(project string, token string) *hcloud.Client {
+	if m.rateLimits == nil {
+		m.rateLimits = make(map[string]*ratelimit.Tracker)
+	}
+	tracker, exists := m.rateLimits[project]
+	if !exists {
+		tracker = ratelimit.NewTracker()
+		m.rateLimits[project] = tracker
+	}
+	return hcloud.NewClient(
+		hcloud.WithToken(token),
+		hcloud.WithHTTPClient(&http.Client{Transport: ratelimit.NewTransport(http.DefaultTransport, tracker)}),
+		hcloud.WithRetryOpts(hcloud.RetryOpts{BackoffFunc: tracker.Backoff, MaxRetries: ratelimit.MaxRetries}),
+	)
+}
+
+// getRateLimitStatus returns the rate limit budget of the current project.
+// In the all-projects view it returns the project with the least budget left.
+func (m Model) getRateLimitStatus() (string, ratelimit.Status) {
+	if !m.aggregated {
+		if tracker, exists := m.rateLimits[m.currentProject]; exists {
+			return m.currentProject, tracker.Status()
+		}
+		return m.currentProject, ratelimit.Status{}
+	}
+
+	var (
+		lowestProject string
+		lowest        ratelimit.Status
+	)
+	for project := range m.projectClients {
+		tracker, exists := m.rateLimits[project]
+		if !exists {
+			continue
+		}
+		status := tracker.Status()
+		if !status.Known {
+			continue
+		}
+		if !lowest.Known || status.Throttled || status.Remaining*lowest.Limit < lowest.Remaining*status.Limit {
+			lowestProject, lowest = project, status
+		}
+	}
+	return lowestProject, lowest
+}
+
+// getRateLimitLabel describes the remaining API budget for the status bar
+func (m Model) getRateLimitLabel() string {
+	project, status := m.getRateLimitStatus()
+	if !status.Known {
+		return ""
+	}
+	label := fmt.Sprintf("API budget: %d/%d", status.Remaining, status.Limit)
+	if m.aggregated && project != "" {
+		label = fmt.Sprintf("API budget (%s): %d/%d", project, status.Remaining, status.Limit)
+	}
+	switch {
+	case status.Throttled:
+		return errorStyle.Render(fmt.Sprintf("⛔ %s • rate limited, retrying", label))
+	case status.Low():
+		resetIn := time.Until(status.Reset).Round(time.Second)
+		if resetIn > 0 {
+			label = fmt.Sprintf("%s • refills in %s", label, resetIn)
+		}
+		return warningStyle.Render(fmt.Sprintf("⚠️  %s • background refresh slowed down", label))
+	default:
+		return helpStyle.Render(label)
+	}
+}
@@ -0,0 +1,249 @@
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/grammeaway/lazyhetzner/internal/config"
+	"github.com/grammeaway/lazyhetzner/internal/registry"
+	"github.com/grammeaway/lazyhetzner/internal/resource"
+)
+
+// How long added, changed and removed resources stay highlighted after a refresh
+const changeHighlightDuration = 5 * time.Second
+
+// Intervals the auto-refresh key cycles through; 0 turns auto-refresh off
+var autoRefreshIntervals = []time.Duration{0, 15 * time.Second, 30 * time.Second, time.Minute, 5 * time.Minute}
+
+type changeKind int
+
+const (
+	changeAdded changeKind = iota + 1
+	changeUpdated
+)
+
+// autoRefreshTickMsg triggers a background refresh of a tab. Ticks from an older schedule are ignored.
+type autoRefreshTickMsg struct {
+	ResourceType resource.ResourceType
+	Generation   int
+}
+
+// clearChangesMsg removes the change highlighting of a tab, unless a newer refresh highlighted it again
+type clearChangesMsg struct {
+	ResourceType resource.ResourceType
+	Generation   int
+}
+
+// getItemFingerprint summarizes the fields a refresh reports as changed: status, IPs and labels
+func getItemFingerprint(kind registry.Kind, item list.Item) string {
+	item = unwrapItem(item)
+	return strings.Join(append([]string{kind.Fingerprint(item)}, getSortedLabelTerms(kind.Labels(item))...), "|")
+}
+
+func getSortedLabelTerms(labels map[string]string) []string {
+	terms := resource.GetLabelTerms(labels)
+	sort.Strings(terms)
+	return terms
+}
+
+// getChangeSet returns the change highlighting for a tab, creating it if needed.
+// Like the marked set, it is shared with the tab's list delegate and must never be replaced.
+func (m *Model) getChangeSet(rt resource.ResourceType) map[int64]changeKind {
+	if m.changedResources == nil {
+		m.changedResources = make(map[resource.ResourceType]map[int64]changeKind)
+	}
+	changes, exists := m.changedResources[rt]
+	if !exists {
+		changes = make(map[int64]changeKind)
+		m.changedResources[rt] = changes
+	}
+	return changes
+}
+
+// setResourceItems shows freshly loaded items in a tab. An existing list is updated in place,
+// keeping the cursor and any active filter, and the differences to the previous items are highlighted.
+func (m *Model) setResourceItems(rt resource.ResourceType, items []list.Item) tea.Cmd {
+	currentList, exists := m.Lists[rt]
+	if !exists {
+		m.Lists[rt] = m.newResourceList(rt, items)
+		return m.scheduleAutoRefresh(rt)
+	}
+
+	previous := make(map[int64]list.Item, len(currentList.Items()))
+	for _, item := range currentList.Items() {
+		if id, ok := getItemResourceID(item); ok {
+			previous[id] = item
+		}
+	}
+	kind, _ := registry.Get(rt)
+	changes := m.getChangeSet(rt)
+	for id := range changes {
+		delete(changes, id)
+	}
+	present := make(map[int64]bool, len(items))
+	for _, item := range items {
+		id, ok := getItemResourceID(item)
+		if !ok {
+			continue
+		}
+		present[id] = true
+		if previousItem, existed := previous[id]; !existed {
+			changes[id] = changeAdded
+		} else if kind != nil && getItemFingerprint(kind, previousItem) != getItemFingerprint(kind, item) {
+			changes[id] = changeUpdated
+		}
+	}
+	removed := []string{}
+	for id, item := range previous {
+		if defaultItem, ok := item.(list.DefaultItem); ok && !present[id] {
+			removed = append(removed, defaultItem.Title())
+		}
+	}
+	sort.Strings(removed)
+	if m.removedResources == nil {
+		m.removedResources = make(map[resource.ResourceType][]string)
+	}
+	m.removedResources[rt] = removed
+
+	replaceItems(&currentList, m.sortItems(rt, items))
+
+	marked := m.getMarkedSet(rt)
+	for id := range marked {
+		if !present[id] {
+			delete(marked, id)
+		}
+	}
+	if m.pendingSelection != nil && m.pendingSelection.ResourceType == rt {
+		currentList.ResetFilter()
+		selectResource(&currentList, m.pendingSelection.ResourceID)
+		m.pendingSelection = nil
+	}
+	m.Lists[rt] = currentList
+
+	if len(changes) == 0 && len(removed) == 0 {
+		return nil
+	}
+	m.changeGeneration++
+	if m.changeGenerations == nil {
+		m.changeGenerations = make(map[resource.ResourceType]int)
+	}
+	m.changeGenerations[rt] = m.changeGeneration
+	generation := m.changeGeneration
+	return tea.Tick(changeHighlightDuration, func(time.Time) tea.Msg {
+		return clearChangesMsg{ResourceType: rt, Generation: generation}
+	})
+}
+
+// replaceItems swaps the items of a list, keeping the filter and the selected resource
+func replaceItems(resourceList *list.Model, items []list.Item) {
+	selectedID, hasSelection := getItemResourceID(resourceList.SelectedItem())
+	selectedIndex := resourceList.Index()
+	if filterCmd := resourceList.SetItems(items); filterCmd != nil {
+		// Re-apply the active filter right away, as the result must not end up in another tab's list
+		*resourceList, _ = resourceList.Update(filterCmd())
+	}
+	if !hasSelection || !selectVisibleResource(resourceList, selectedID) {
+		resourceList.Select(min(selectedIndex, max(0, len(resourceList.VisibleItems())-1)))
+	}
+}
+
+// selectVisibleResource moves the cursor to the resource among the items matching the current filter,
+// reporting whether it is among them
+func selectVisibleResource(resourceList *list.Model, id int64) bool {
+	for i, item := range resourceList.VisibleItems() {
+		if itemID, ok := getItemResourceID(item); ok && itemID == id {
+			resourceList.Select(i)
+			return true
+		}
+	}
+	return false
+}
+
+func (m *Model) clearChanges(rt resource.ResourceType, generation int) {
+	if m.changeGenerations[rt] != generation {
+		return
+	}
+	changes := m.getChangeSet(rt)
+	for id := range changes {
+		delete(changes, id)
+	}
+	delete(m.removedResources, rt)
+}
+
+func (m *Model) getAutoRefreshInterval(rt resource.ResourceType) time.Duration {
+	if m.config == nil {
+		return 0
+	}
+	return m.config.GetAutoRefreshInterval(resource.GetResourceKeyFromType(rt))
+}
+
+// scheduleAutoRefresh starts a new refresh schedule for a tab, replacing any earlier one
+func (m *Model) scheduleAutoRefresh(rt resource.ResourceType) tea.Cmd {
+	if m.refreshGenerations == nil {
+		m.refreshGenerations = make(map[resource.ResourceType]int)
+	}
+	m.refreshGeneration++
+	m.refreshGenerations[rt] = m.refreshGeneration
+
+	interval := m.getAutoRefreshInterval(rt)
+	if interval <= 0 {
+		return nil
+	}
+	// Stretch the interval while the API budget is running low
+	_, rateLimit := m.getRateLimitStatus()
+	interval *= time.Duration(rateLimit.SlowdownFactor())
+	generation := m.refreshGeneration
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return autoRefreshTickMsg{ResourceType: rt, Generation: generation}
+	})
+}
+
+// handleAutoRefreshTick reloads a tab in the background and schedules the next refresh
+func (m *Model) handleAutoRefreshTick(msg autoRefreshTickMsg) tea.Cmd {
+	if m.refreshGenerations[msg.ResourceType] != msg.Generation || !m.hasClient() || m.State == StateProjectSelect {
+		return nil
+	}
+	return tea.Batch(m.loadResource(msg.ResourceType), m.scheduleAutoRefresh(msg.ResourceType))
+}
+
+// cycleAutoRefresh switches the active tab to the next auto-refresh interval and saves it in the config
+func (m *Model) cycleAutoRefresh() tea.Cmd {
+	if m.config == nil {
+		return nil
+	}
+	current := m.getAutoRefreshInterval(m.activeTab)
+	next := autoRefreshIntervals[0]
+	for i, interval := range autoRefreshIntervals {
+		if interval == current {
+			next = autoRefreshIntervals[(i+1)%len(autoRefreshIntervals)]
+			break
+		}
+	}
+	m.config.SetAutoRefreshInterval(resource.GetResourceKeyFromType(m.activeTab), next)
+	return tea.Batch(
+		m.scheduleAutoRefresh(m.activeTab),
+		config.SaveConfigCmd(m.config),
+	)
+}
+
+// getAutoRefreshLabel describes the auto-refresh interval of the active tab for the header
+func (m Model) getAutoRefreshLabel() string {
+	interval := m.getAutoRefreshInterval(m.activeTab)
+	if interval <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("🔄 every %s", interval)
+}
+
+// renderRemovedResources lists the resources that disappeared in the last refresh of the active tab
+func (m Model) renderRemovedResources() string {
+	removed := m.removedResources[m.activeTab]
+	if len(removed) == 0 {
+		return ""
+	}
+	return errorStyle.Render("✖ removed: "+strings.Join(removed, ", ")) + "\n"
+}
@@ -0,0 +1,100 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/grammeaway/lazyhetzner/internal/message"
+	"github.com/grammeaway/lazyhetzner/internal/resource"
+)
+
+// resourceLoad is an in-flight load of a tab
+type resourceLoad struct {
+	ID     int
+	Cancel context.CancelFunc
+}
+
+// resourceLoadedMsg carries the result of a tab load. Results of loads that were cancelled,
+// superseded or started for a previous project no longer match the in-flight load and are discarded.
+type resourceLoadedMsg struct {
+	ResourceType resource.ResourceType
+	LoadID       int
+	Msg          tea.Msg
+}
+
+// getSessionContext returns the context of the current project. It is cancelled when the project is left.
+func (m *Model) getSessionContext() context.Context {
+	if m.sessionCtx == nil {
+		m.sessionCtx, m.cancelSession = context.WithCancel(context.Background())
+	}
+	return m.sessionCtx
+}
+
+// endSession cancels every in-flight request of the current project
+func (m *Model) endSession() {
+	if m.cancelSession != nil {
+		m.cancelSession()
+	}
+	m.sessionCtx, m.cancelSession = nil, nil
+	m.loadingResources = nil
+}
+
+// requestContext returns a context for a load or lookup in the current project, bounded by the request timeout
+func (m *Model) requestContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(m.getSessionContext(), m.config.GetRequestTimeout())
+}
+
+// withRequestContext runs a request of the current project with a timeout. Requests cancelled
+// because the project was left produce no message, so they can't replace the current view.
+func (m *Model) withRequestContext(build func(ctx context.Context) tea.Cmd) tea.Cmd {
+	ctx, cancel := m.requestContext()
+	return runWithContext(build(ctx), cancel, m.config.GetRequestTimeout())
+}
+
+// withActionContext runs an action with the action timeout. Actions change resources, so leaving
+// the project doesn't cancel them.
+func (m *Model) withActionContext(build func(ctx context.Context) tea.Cmd) tea.Cmd {
+	timeout := m.config.GetActionTimeout()
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	return runWithContext(build(ctx), cancel, timeout)
+}
+
+func runWithContext(cmd tea.Cmd, cancel context.CancelFunc, timeout time.Duration) tea.Cmd {
+	if cmd == nil {
+		cancel()
+		return nil
+	}
+	return func() tea.Msg {
+		defer cancel()
+		msg := cmd()
+		if errMsg, ok := msg.(message.ErrorMsg); ok {
+			if errors.Is(errMsg.Err, context.Canceled) {
+				return nil
+			}
+			return message.ErrorMsg{Err: describeRequestError(errMsg.Err, timeout)}
+		}
+		return msg
+	}
+}
+
+// describeRequestError replaces the generic deadline error with the timeout that was hit
+func describeRequestError(err error, timeout time.Duration) error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("request timed out after %s: %w", timeout, err)
+	}
+	return err
+}
+
+// cancelResourceLoad cancels the in-flight load of a tab, if any
+func (m *Model) cancelResourceLoad(rt resource.ResourceType) {
+	if load := m.loadingResources[rt]; load != nil {
+		load.Cancel()
+		delete(m.loadingResources, rt)
+	}
+	if m.loadingResource == rt {
+		m.IsLoading = false
+	}
+}
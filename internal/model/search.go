@@ -0,0 +1,216 @@
+package model
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/grammeaway/lazyhetzner/internal/resource"
+	"github.com/grammeaway/lazyhetzner/internal/search"
+)
+
+// pendingSelection is a resource to select once its tab has finished loading
+type pendingSelection struct {
+	ResourceType resource.ResourceType
+	ResourceID   int64
+}
+
+// searchable is implemented by list items that take part in the global search
+type searchable interface {
+	SearchTerms() []string
+	Title() string
+}
+
+func (m *Model) openSearch() tea.Cmd {
+	m.searchInput = textinput.New()
+	m.searchInput.Placeholder = "Search by name, ID, IP, label or attached server..."
+	m.searchInput.CharLimit = 128
+	m.searchInput.Width = min(70, m.width-10)
+	m.searchInput.Focus()
+	m.searchCursor = 0
+	m.State = stateSearch
+
+	// Search covers every resource type, so load the tabs that haven't been opened yet
+	cmds := []tea.Cmd{textinput.Blink}
+	for _, rt := range getTabs() {
+		if !m.LoadedResources[rt] {
+			cmds = append(cmds, m.loadResource(rt))
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// crossProjectSearchLoadedMsg holds a resource type loaded from every project for the cross-project search
+type crossProjectSearchLoadedMsg struct {
+	aggregatedResourcesLoadedMsg
+}
+
+// searchesAllProjects reports whether search results come from every configured project
+func (m Model) searchesAllProjects() bool {
+	return m.aggregated || m.searchAllProjects
+}
+
+// toggleSearchScope switches the search between the current project and all configured projects
+func (m *Model) toggleSearchScope() tea.Cmd {
+	if m.aggregated || m.config == nil || len(m.config.Projects) == 0 {
+		return nil
+	}
+	m.searchAllProjects = !m.searchAllProjects
+	m.searchCursor = 0
+	if !m.searchAllProjects {
+		return nil
+	}
+
+	// Always load fresh results, since resources in other projects may have changed since the last search
+	m.buildProjectClients()
+	m.crossProjectItems = make(map[resource.ResourceType][]list.Item)
+	m.crossProjectErrors = make(map[string]error)
+	cmds := []tea.Cmd{}
+	for _, rt := range getTabs() {
+		clients := m.projectClients
+		serverIndex := m.getServerIndex()
+		timeout := m.config.GetRequestTimeout()
+		cmds = append(cmds, func() tea.Msg {
+			// Searches span every project, so they aren't tied to the current project's session
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+			// The label selectors filter the current project's tabs, so other projects are searched unfiltered
+			return crossProjectSearchLoadedMsg{loadFromProjects(ctx, clients, rt, "", serverIndex)}
+		})
+	}
+	return tea.Batch(cmds...)
+}
+
+func (m Model) getSearchEntries() []search.Entry {
+	entries := []search.Entry{}
+	for _, rt := range getTabs() {
+		var items []list.Item
+		if m.searchAllProjects && !m.aggregated {
+			items = m.crossProjectItems[rt]
+		} else if currentList, exists := m.Lists[rt]; exists {
+			items = currentList.Items()
+		}
+		for _, item := range items {
+			searchableItem, ok := item.(searchable)
+			if !ok {
+				continue
+			}
+			id, ok := getItemResourceID(item)
+			if !ok {
+				continue
+			}
+			entries = append(entries, search.Entry{
+				ResourceType: rt,
+				ID:           id,
+				Name:         searchableItem.Title(),
+				Terms:        searchableItem.SearchTerms(),
+				Project:      getItemProject(item),
+			})
+		}
+	}
+	return entries
+}
+
+func (m Model) getSearchMatches() []search.Match {
+	return search.Search(m.searchInput.Value(), m.getSearchEntries())
+}
+
+// jumpToResource switches to the tab of the resource and selects it in the list.
+// If the tab isn't loaded yet, the selection is applied once it is.
+func (m *Model) jumpToResource(rt resource.ResourceType, id int64) tea.Cmd {
+	m.activeTab = rt
+	m.State = stateResourceView
+
+	currentList, exists := m.Lists[rt]
+	if !exists || !m.LoadedResources[rt] {
+		m.pendingSelection = &pendingSelection{ResourceType: rt, ResourceID: id}
+		return m.loadResource(rt)
+	}
+	currentList.ResetFilter()
+	selectResource(&currentList, id)
+	m.Lists[rt] = currentList
+	return nil
+}
+
+func selectResource(resourceList *list.Model, id int64) {
+	for i, item := range resourceList.Items() {
+		if itemID, ok := getItemResourceID(item); ok && itemID == id {
+			resourceList.Select(i)
+			return
+		}
+	}
+}
+
+func (m Model) renderSearch() string {
+	var searchView strings.Builder
+	searchView.WriteString(m.searchInput.View() + "\n\n")
+	if m.searchAllProjects && !m.aggregated {
+		if loaded := len(m.crossProjectItems); loaded < len(getTabs()) {
+			searchView.WriteString(infoStyle.Render(fmt.Sprintf("Loading resources from %d project(s)... (%d/%d resource types)", len(m.projectClients), loaded, len(getTabs()))) + "\n\n")
+		}
+		projects := make([]string, 0, len(m.crossProjectErrors))
+		for project := range m.crossProjectErrors {
+			projects = append(projects, project)
+		}
+		sort.Strings(projects)
+		for _, project := range projects {
+			searchView.WriteString(errorStyle.Render(fmt.Sprintf("⚠️  %s: %v", project, m.crossProjectErrors[project])) + "\n")
+		}
+		if len(projects) > 0 {
+			searchView.WriteString("\n")
+		}
+	}
+
+	matches := m.getSearchMatches()
+	if strings.TrimSpace(m.searchInput.Value()) == "" {
+		searchView.WriteString(helpStyle.Render("Start typing to search servers, networks, load balancers, floating IPs, firewalls and volumes."))
+	} else if len(matches) == 0 {
+		searchView.WriteString(warningStyle.Render("No matching resources found."))
+	} else {
+		lines := []string{}
+		cursorLine := 0
+		for i, match := range matches {
+			if i == 0 || matches[i-1].Entry.ResourceType != match.Entry.ResourceType {
+				lines = append(lines, detailTitleStyle.Render(resource.GetResourceNameFromType(match.Entry.ResourceType)))
+			}
+			line := fmt.Sprintf("%s (ID: %d)", match.Entry.Name, match.Entry.ID)
+			if match.MatchedTerm != match.Entry.Name {
+				line = fmt.Sprintf("%s %s", line, helpStyle.Render("· "+match.MatchedTerm))
+			}
+			if i == m.searchCursor {
+				cursorLine = len(lines)
+				line = selectedMenuStyle.Render(line)
+			} else {
+				line = "  " + line
+			}
+			lines = append(lines, line)
+		}
+
+		// Only show the window of results around the cursor
+		visible := max(5, m.height-14)
+		start := 0
+		if cursorLine >= visible {
+			start = cursorLine - visible + 1
+		}
+		end := min(len(lines), start+visible)
+		searchView.WriteString(fmt.Sprintf("Found %d resource(s):\n\n", len(matches)))
+		searchView.WriteString(strings.Join(lines[start:end], "\n"))
+	}
+
+	title := "Search all resources"
+	helpText := "\n\n↑/↓ to navigate • Enter to jump to resource • Tab: search all projects • Esc to close"
+	if m.searchesAllProjects() {
+		title = "Search all resources in all projects"
+		helpText = "\n\n↑/↓ to navigate • Enter to jump to resource • Esc to close"
+		if !m.aggregated {
+			helpText = "\n\n↑/↓ to navigate • Enter to switch project and jump to resource • Tab: current project only • Esc to close"
+		}
+	}
+	overlay := menuStyle.Width(min(100, max(40, m.width-8))).Render(fmt.Sprintf("%s\n\n%s%s", title, searchView.String(), helpStyle.Render(helpText)))
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, overlay)
+}
@@ -0,0 +1,132 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/grammeaway/lazyhetzner/internal/config"
+	"github.com/grammeaway/lazyhetzner/internal/registry"
+	"github.com/grammeaway/lazyhetzner/internal/resource"
+)
+
+// getSortKeys returns the fields a tab can be sorted by, taking items as they are stored in its list
+func getSortKeys(rt resource.ResourceType) []registry.SortKey {
+	kind, exists := registry.Get(rt)
+	if !exists {
+		return nil
+	}
+	sortKeys := kind.SortKeys()
+	for i, sortKey := range sortKeys {
+		less := sortKey.Less
+		sortKeys[i].Less = func(a, b list.Item) bool { return less(unwrapItem(a), unwrapItem(b)) }
+	}
+	return sortKeys
+}
+
+// cycleSortOrder sorts the active tab by the next field in ascending, then in descending order.
+// After the last field, the tab goes back to the order of the API.
+func (m *Model) cycleSortOrder() tea.Cmd {
+	sortKeys := getSortKeys(m.activeTab)
+	if m.config == nil || len(sortKeys) == 0 {
+		return nil
+	}
+	current := m.config.GetSortOrder(resource.GetResourceKeyFromType(m.activeTab))
+	orders := []config.SortOrder{{}}
+	for _, sortKey := range sortKeys {
+		orders = append(orders, config.SortOrder{Field: sortKey.Key}, config.SortOrder{Field: sortKey.Key, Descending: true})
+	}
+	next := orders[0]
+	if i := slices.Index(orders, current); i >= 0 {
+		next = orders[(i+1)%len(orders)]
+	} else if current.Field != "" {
+		// Sorted by a column without a sort key, so start over with the first field
+		next = orders[1]
+	}
+	return m.setSortOrder(next)
+}
+
+// setSortOrder changes the sort order of the active tab, re-sorts its list and saves the config
+func (m *Model) setSortOrder(order config.SortOrder) tea.Cmd {
+	m.config.SetSortOrder(resource.GetResourceKeyFromType(m.activeTab), order)
+	if currentList, exists := m.Lists[m.activeTab]; exists {
+		// Sort the items as loaded, so going back to the order of the API restores it
+		items := currentList.Items()
+		if entry, cached := m.getResourceCache().Get(m.activeTab); cached {
+			items = entry.Value
+		}
+		replaceItems(&currentList, m.sortItems(m.activeTab, items))
+		m.Lists[m.activeTab] = currentList
+	}
+	return config.SaveConfigCmd(m.config)
+}
+
+// sortItems orders items by the sort order of their tab. Without one, the order of the API is kept.
+func (m Model) sortItems(rt resource.ResourceType, items []list.Item) []list.Item {
+	order := m.config.GetSortOrder(resource.GetResourceKeyFromType(rt))
+	if order.Field == "" {
+		return items
+	}
+	less := m.getSortLess(rt, order.Field)
+	if less == nil {
+		return items
+	}
+	sorted := slices.Clone(items)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if order.Descending {
+			return less(sorted[j], sorted[i])
+		}
+		return less(sorted[i], sorted[j])
+	})
+	return sorted
+}
+
+// getSortLess returns how a tab's items are ordered by a field, or nil if the tab has no such field.
+// Fields are sort keys, or columns of the table mode.
+func (m Model) getSortLess(rt resource.ResourceType, field string) func(a, b list.Item) bool {
+	for _, sortKey := range getSortKeys(rt) {
+		if sortKey.Key == field {
+			return sortKey.Less
+		}
+	}
+	for _, column := range m.getAllColumns(rt) {
+		if column.Key != field {
+			continue
+		}
+		if column.Less != nil {
+			return column.Less
+		}
+		value := column.Value
+		return func(a, b list.Item) bool {
+			return strings.ToLower(value(a)) < strings.ToLower(value(b))
+		}
+	}
+	return nil
+}
+
+// getSortLabel describes the sort order of a tab for the header, or returns an empty string if it isn't sorted
+func (m Model) getSortLabel(rt resource.ResourceType) string {
+	order := m.config.GetSortOrder(resource.GetResourceKeyFromType(rt))
+	if order.Field == "" || m.getSortLess(rt, order.Field) == nil {
+		return ""
+	}
+	title := order.Field
+	for _, column := range m.getAllColumns(rt) {
+		if column.Key == order.Field {
+			title = strings.ToLower(column.Title)
+		}
+	}
+	for _, sortKey := range getSortKeys(rt) {
+		if sortKey.Key == order.Field {
+			title = sortKey.Title
+		}
+	}
+	direction := "▲"
+	if order.Descending {
+		direction = "▼"
+	}
+	return fmt.Sprintf("sorted by %s %s", title, direction)
+}
@@ -8,14 +8,21 @@ const (
 	stateProjectManage
 	stateTerminalConfig
 	stateTokenInput
+	stateTokenUnlock
 	stateLoading
 	stateResourceView
 	stateLabelView
 	stateContextMenu
-	stateLoadBalancerServiceView
-	stateLoadBalancerTargetView
-	stateFirewallRuleView
-	stateNetworkSubnetView
-	stateServerDetailView
+	stateDetailView
+	stateBulkMenu
+	stateBulkInput
+	stateConfirmInput
+	stateBulkResultView
+	stateLabelSelectorInput
+	stateSearch
+	stateIPLookup
+	stateTopologyView
+	stateCostView
+	stateAuditView
 	stateError
 )
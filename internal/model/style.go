@@ -73,14 +73,7 @@ var (
 			BorderForeground(lipgloss.Color("#FFAA00")).
 			Italic(true)
 
-	targetStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFDF5")).
-			Background(lipgloss.Color("#1a1a1a")).
-			Padding(0, 1).
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("#626262"))
-
-	noTargetsStyle = lipgloss.NewStyle().
+	noDetailsStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#FFAA00")).
 			Background(lipgloss.Color("#2a1a00")).
 			Padding(1, 2).
@@ -88,77 +81,47 @@ var (
 			BorderForeground(lipgloss.Color("#FFAA00")).
 			Italic(true)
 
-	targetContainerStyle = lipgloss.NewStyle().
-				Margin(0, 0, 1, 0).
-				Padding(1).
-				Border(lipgloss.RoundedBorder()).
-				BorderForeground(lipgloss.Color("#444444")).
-				Background(lipgloss.Color("#0f0f0f"))
-
-	serviceStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFDF5")).
-			Background(lipgloss.Color("#1a1a1a")).
-			Padding(0, 1).
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("#626262"))
-	noServicesStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFAA00")).
-			Background(lipgloss.Color("#2a1a00")).
-			Padding(1, 2).
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("#FFAA00")).
-			Italic(true)
-	serviceContainerStyle = lipgloss.NewStyle().
+	detailSectionStyle = lipgloss.NewStyle().
 				Margin(0, 0, 1, 0).
-				Padding(1).
-				Border(lipgloss.RoundedBorder()).
-				BorderForeground(lipgloss.Color("#444444")).
-				Background(lipgloss.Color("#0f0f0f"))
-	firewallRuleStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#FFFDF5")).
-				Background(lipgloss.Color("#1a1a1a")).
 				Padding(0, 1).
 				Border(lipgloss.RoundedBorder()).
-				BorderForeground(lipgloss.Color("#626262"))
-	noFirewallRulesStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#FFAA00")).
-				Background(lipgloss.Color("#2a1a00")).
-				Padding(1, 2).
-				Border(lipgloss.RoundedBorder()).
-				BorderForeground(lipgloss.Color("#FFAA00")).
-				Italic(true)
-	firewallRuleContainerStyle = lipgloss.NewStyle().
-					Margin(0, 0, 1, 0).
-					Padding(1).
-					Border(lipgloss.RoundedBorder()).
-					BorderForeground(lipgloss.Color("#444444")).
-					Background(lipgloss.Color("#0f0f0f"))
-	subnetStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFFDF5")).
-			Background(lipgloss.Color("#1a1a1a")).
-			Padding(0, 1).
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("#626262"))
-	noSubnetsStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFAA00")).
-			Background(lipgloss.Color("#2a1a00")).
-			Padding(1, 2).
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("#FFAA00")).
-			Italic(true)
-	subnetContainerStyle = lipgloss.NewStyle().
-				Margin(0, 0, 1, 0).
-				Padding(1).
-				Border(lipgloss.RoundedBorder()).
-				BorderForeground(lipgloss.Color("#444444")).
-				Background(lipgloss.Color("#0f0f0f"))
-	serverDetailSectionStyle = lipgloss.NewStyle().
-					Margin(0, 0, 1, 0).
-					Padding(0,1).
-					Border(lipgloss.RoundedBorder()).
-					BorderForeground(lipgloss.Color("#3b82f6")).
-					Background(lipgloss.Color("#0b1a2b"))
-	serverDetailTitleStyle = lipgloss.NewStyle().
+				BorderForeground(lipgloss.Color("#3b82f6")).
+				Background(lipgloss.Color("#0b1a2b"))
+	detailTitleStyle = lipgloss.NewStyle().
 				Foreground(lipgloss.Color("#93c5fd")).
 				Bold(true)
+
+	tableRowStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.AdaptiveColor{Light: "#1a1a1a", Dark: "#dddddd"}).
+			Padding(0, 0, 0, 2)
+	tableSelectedRowStyle = lipgloss.NewStyle().
+				Border(lipgloss.NormalBorder(), false, false, false, true).
+				BorderForeground(lipgloss.AdaptiveColor{Light: "#F793FF", Dark: "#AD58B4"}).
+				Foreground(lipgloss.AdaptiveColor{Light: "#EE6FF8", Dark: "#EE6FF8"}).
+				Padding(0, 0, 0, 1)
+	tableHeaderStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#93c5fd")).
+				Bold(true).
+				Padding(0, 0, 0, 2)
+	tableFocusedHeaderStyle = lipgloss.NewStyle().
+				Underline(true)
+
+	topologyNetworkStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#3b82f6")).
+				Bold(true)
+	topologySubnetStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#93c5fd"))
+	topologyLoadBalancerStyle = lipgloss.NewStyle().
+					Foreground(lipgloss.Color("#874BFD")).
+					Bold(true)
+	topologyServerStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#04B575"))
+	topologyAttachmentStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#FFAA00"))
+
+	protectedBannerStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#FFFDF5")).
+				Background(lipgloss.Color("#CC0000")).
+				Bold(true).
+				Padding(0, 1)
 )
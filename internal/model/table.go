@@ -0,0 +1,280 @@
+package model
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/grammeaway/lazyhetzner/internal/config"
+	"github.com/grammeaway/lazyhetzner/internal/registry"
+	"github.com/grammeaway/lazyhetzner/internal/resource"
+	"github.com/mattn/go-runewidth"
+)
+
+// Bounds for resizing table columns
+const (
+	minColumnWidth = 4
+	maxColumnWidth = 80
+)
+
+// Cells are separated by this gap, and rows start with a column for the item's flags
+const (
+	columnGap     = "  "
+	flagsWidth    = 1
+	projectColKey = "project"
+)
+
+// tableDelegate renders every item as a single row of cells
+type tableDelegate struct {
+	columns []registry.Column
+	marked  map[int64]bool
+	changes map[int64]changeKind
+}
+
+func (d tableDelegate) Height() int                             { return 1 }
+func (d tableDelegate) Spacing() int                            { return 0 }
+func (d tableDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd { return nil }
+
+func (d tableDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	flags := ""
+	style := tableRowStyle
+	if index == m.Index() {
+		style = tableSelectedRowStyle
+	}
+	id, hasID := getItemResourceID(item)
+	if hasID {
+		switch d.changes[id] {
+		case changeAdded:
+			flags = "✚"
+			style = style.Foreground(lipgloss.Color("#00FF88"))
+		case changeUpdated:
+			flags = "●"
+			style = style.Foreground(lipgloss.Color("#00AAFF"))
+		}
+		if d.marked[id] {
+			flags = "✔"
+			style = style.Foreground(lipgloss.Color("#FFAA00"))
+		}
+	}
+
+	cells := make([]string, 0, len(d.columns)+1)
+	cells = append(cells, fitCell(flags, flagsWidth))
+	for _, column := range d.columns {
+		cells = append(cells, fitCell(column.Value(item), column.Width))
+	}
+	row := runewidth.Truncate(strings.Join(cells, columnGap), max(0, m.Width()-2), "…")
+	fmt.Fprint(w, style.Render(row))
+}
+
+// fitCell truncates or pads a value to exactly the width of its column
+func fitCell(value string, width int) string {
+	value = strings.Join(strings.Fields(value), " ")
+	return runewidth.FillRight(runewidth.Truncate(value, width, "…"), width)
+}
+
+func (m Model) getTableLayout(rt resource.ResourceType) config.TableLayout {
+	return m.config.GetTableLayout(resource.GetResourceKeyFromType(rt))
+}
+
+func (m Model) isTableMode(rt resource.ResourceType) bool {
+	return m.getTableLayout(rt).Enabled
+}
+
+// getAllColumns returns every column of a tab, taking items as they are stored in its list.
+// The all-projects view adds a column for the project of each item.
+func (m Model) getAllColumns(rt resource.ResourceType) []registry.Column {
+	kind, exists := registry.Get(rt)
+	if !exists {
+		return nil
+	}
+	columns := []registry.Column{}
+	if m.aggregated {
+		columns = append(columns, registry.Column{Key: projectColKey, Title: "Project", Width: 12, Value: getItemProject})
+	}
+	for _, column := range kind.Columns() {
+		value, less := column.Value, column.Less
+		column.Value = func(item list.Item) string { return value(unwrapItem(item)) }
+		if less != nil {
+			column.Less = func(a, b list.Item) bool { return less(unwrapItem(a), unwrapItem(b)) }
+		}
+		columns = append(columns, column)
+	}
+	return columns
+}
+
+// getTableColumns returns the columns a tab shows in table mode, with their configured widths
+func (m Model) getTableColumns(rt resource.ResourceType) []registry.Column {
+	layout := m.getTableLayout(rt)
+	columns := []registry.Column{}
+	for _, column := range m.getAllColumns(rt) {
+		if slices.Contains(layout.Hidden, column.Key) {
+			continue
+		}
+		if width, ok := layout.Widths[column.Key]; ok {
+			column.Width = width
+		}
+		columns = append(columns, column)
+	}
+	return columns
+}
+
+// getFocusedColumn returns the table column that sorting, resizing and hiding apply to
+func (m Model) getFocusedColumn(rt resource.ResourceType) (registry.Column, bool) {
+	columns := m.getTableColumns(rt)
+	if len(columns) == 0 {
+		return registry.Column{}, false
+	}
+	return columns[min(m.focusedColumns[rt], len(columns)-1)], true
+}
+
+func (m *Model) newResourceDelegate(rt resource.ResourceType) list.ItemDelegate {
+	if m.isTableMode(rt) {
+		return tableDelegate{columns: m.getTableColumns(rt), marked: m.getMarkedSet(rt), changes: m.getChangeSet(rt)}
+	}
+	return resourceDelegate{DefaultDelegate: list.NewDefaultDelegate(), marked: m.getMarkedSet(rt), changes: m.getChangeSet(rt)}
+}
+
+// styleResourceList adapts a list to the mode of its tab. In table mode, the status bar
+// loses its bottom padding to make room for the column headers.
+func (m *Model) styleResourceList(rt resource.ResourceType, resourceList *list.Model) {
+	resourceList.Styles.StatusBar = list.DefaultStyles().StatusBar
+	if m.isTableMode(rt) {
+		resourceList.Styles.StatusBar = resourceList.Styles.StatusBar.PaddingBottom(0)
+	}
+}
+
+// updateTableLayout changes the table layout of the active tab, re-renders its list and saves the config
+func (m *Model) updateTableLayout(change func(layout *config.TableLayout)) tea.Cmd {
+	if m.config == nil {
+		return nil
+	}
+	tab := resource.GetResourceKeyFromType(m.activeTab)
+	layout := m.config.GetTableLayout(tab)
+	layout.Hidden = slices.Clone(layout.Hidden)
+	widths := make(map[string]int, len(layout.Widths))
+	for key, width := range layout.Widths {
+		widths[key] = width
+	}
+	layout.Widths = widths
+	change(&layout)
+	m.config.SetTableLayout(tab, layout)
+
+	if currentList, exists := m.Lists[m.activeTab]; exists {
+		currentList.SetDelegate(m.newResourceDelegate(m.activeTab))
+		m.styleResourceList(m.activeTab, &currentList)
+		m.Lists[m.activeTab] = currentList
+	}
+	return config.SaveConfigCmd(m.config)
+}
+
+func (m *Model) toggleTableMode() tea.Cmd {
+	return m.updateTableLayout(func(layout *config.TableLayout) {
+		layout.Enabled = !layout.Enabled
+	})
+}
+
+// moveColumnFocus moves the focus to the previous or next visible column
+func (m *Model) moveColumnFocus(offset int) {
+	columns := m.getTableColumns(m.activeTab)
+	if len(columns) == 0 {
+		return
+	}
+	if m.focusedColumns == nil {
+		m.focusedColumns = make(map[resource.ResourceType]int)
+	}
+	focused := min(m.focusedColumns[m.activeTab], len(columns)-1)
+	m.focusedColumns[m.activeTab] = max(0, min(len(columns)-1, focused+offset))
+}
+
+func (m *Model) resizeFocusedColumn(delta int) tea.Cmd {
+	column, ok := m.getFocusedColumn(m.activeTab)
+	if !ok {
+		return nil
+	}
+	return m.updateTableLayout(func(layout *config.TableLayout) {
+		layout.Widths[column.Key] = max(minColumnWidth, min(maxColumnWidth, column.Width+delta))
+	})
+}
+
+// hideFocusedColumn hides the focused column, as long as another column is still shown
+func (m *Model) hideFocusedColumn() tea.Cmd {
+	column, ok := m.getFocusedColumn(m.activeTab)
+	if !ok || len(m.getTableColumns(m.activeTab)) == 1 {
+		return nil
+	}
+	return m.updateTableLayout(func(layout *config.TableLayout) {
+		layout.Hidden = append(layout.Hidden, column.Key)
+	})
+}
+
+// showAllColumns shows the hidden columns again and resets the column widths
+func (m *Model) showAllColumns() tea.Cmd {
+	return m.updateTableLayout(func(layout *config.TableLayout) {
+		layout.Hidden = nil
+		layout.Widths = nil
+	})
+}
+
+// sortByFocusedColumn sorts the active tab by the focused column. Sorting by the same column again reverses the order.
+func (m *Model) sortByFocusedColumn() tea.Cmd {
+	column, ok := m.getFocusedColumn(m.activeTab)
+	if !ok || m.config == nil {
+		return nil
+	}
+	tab := resource.GetResourceKeyFromType(m.activeTab)
+	order := m.config.GetSortOrder(tab)
+	if order.Field == column.Key {
+		order.Descending = !order.Descending
+	} else {
+		order = config.SortOrder{Field: column.Key}
+	}
+	return m.setSortOrder(order)
+}
+
+// renderTableHeader renders the column titles of a tab, marking the focused column and the sort order
+func (m Model) renderTableHeader(rt resource.ResourceType, width int) string {
+	order := m.config.GetSortOrder(resource.GetResourceKeyFromType(rt))
+	focused, _ := m.getFocusedColumn(rt)
+	cells := []string{strings.Repeat(" ", flagsWidth)}
+	for _, column := range m.getTableColumns(rt) {
+		title := column.Title
+		if order.Field == column.Key {
+			if order.Descending {
+				title += " ▼"
+			} else {
+				title += " ▲"
+			}
+		}
+		cell := fitCell(title, column.Width)
+		if column.Key == focused.Key {
+			cell = tableFocusedHeaderStyle.Render(cell)
+		}
+		cells = append(cells, cell)
+	}
+	header := strings.Join(cells, columnGap)
+	if lipgloss.Width(header) > width {
+		// Cut off columns that don't fit, like the rows below
+		header = lipgloss.NewStyle().MaxWidth(width).Render(header)
+	}
+	return tableHeaderStyle.Render(header)
+}
+
+// renderResourceList renders the list of a tab. In table mode, the column headers are shown
+// between the list's status bar and its rows.
+func (m Model) renderResourceList(rt resource.ResourceType, resourceList list.Model) string {
+	view := resourceList.View()
+	if !m.isTableMode(rt) {
+		return view
+	}
+	// The title bar takes two lines and the unpadded status bar one
+	lines := strings.SplitN(view, "\n", 4)
+	if len(lines) < 4 {
+		return view
+	}
+	header := m.renderTableHeader(rt, max(0, resourceList.Width()-2))
+	return strings.Join(append(lines[:3], header, lines[3]), "\n")
+}
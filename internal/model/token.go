@@ -0,0 +1,190 @@
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/grammeaway/lazyhetzner/internal/config"
+	"github.com/grammeaway/lazyhetzner/internal/message"
+	"github.com/grammeaway/lazyhetzner/internal/secret"
+)
+
+// resolveTokens reads the tokens of projects kept outside of config.json, asking for the vault passphrase first if needed
+func (m *Model) resolveTokens() tea.Cmd {
+	if !m.config.UsesTokenBackends() {
+		return m.openDefaultProject()
+	}
+	m.State = stateTokenUnlock
+	if m.config.UsesTokenBackend(config.TokenBackendVault) {
+		m.passphraseInput = textinput.New()
+		m.passphraseInput.Placeholder = "vault passphrase"
+		m.passphraseInput.EchoMode = textinput.EchoPassword
+		m.passphraseInput.CharLimit = 256
+		m.passphraseInput.Width = min(50, m.width-10)
+		m.passphraseInput.Focus()
+		return textinput.Blink
+	}
+	m.resolvingTokens = true
+	return m.config.ResolveTokensCmd("")
+}
+
+func (m *Model) submitPassphrase() tea.Cmd {
+	if m.resolvingTokens || m.passphraseInput.Value() == "" {
+		return nil
+	}
+	m.resolvingTokens = true
+	m.unlockErr = nil
+	return m.config.ResolveTokensCmd(m.passphraseInput.Value())
+}
+
+// skipVault leaves the vault locked, so that the projects keeping their token elsewhere can still be used
+// when the passphrase is forgotten or the vault is missing
+func (m *Model) skipVault() tea.Cmd {
+	if m.resolvingTokens {
+		return nil
+	}
+	m.resolvingTokens = true
+	m.unlockErr = nil
+	m.passphraseInput.Reset()
+	return m.config.ResolveTokensCmd("")
+}
+
+func (m *Model) handleTokensResolved(msg config.TokensResolvedMsg) tea.Cmd {
+	m.resolvingTokens = false
+	if msg.UnlockErr != nil {
+		m.unlockErr = msg.UnlockErr
+		m.passphraseInput.Reset()
+		return nil
+	}
+	m.passphraseInput.Reset()
+	m.passphraseInput.Blur()
+	m.vault = msg.Vault
+	m.tokenErrors = msg.Errors
+	m.config.SetTokens(msg.Tokens)
+	m.updateProjectList()
+	if len(msg.Errors) == 0 {
+		return m.openDefaultProject()
+	}
+	failed := make([]string, 0, len(msg.Errors))
+	for name := range msg.Errors {
+		failed = append(failed, name)
+	}
+	slices.Sort(failed)
+	m.statusMessage = "⚠️  The tokens of these projects couldn't be read: " + strings.Join(failed, ", ")
+	return tea.Batch(m.openDefaultProject(), clearStatusMessage())
+}
+
+// openDefaultProject loads the resources of the default project, if there is one and its token could be read
+func (m *Model) openDefaultProject() tea.Cmd {
+	m.State = StateProjectSelect
+	if m.config.DefaultProject == "" {
+		return nil
+	}
+	project := m.config.GetProject(m.config.DefaultProject)
+	if project == nil {
+		return nil
+	}
+	if err := m.getTokenError(*project); err != nil {
+		m.statusMessage = "⚠️  " + err.Error()
+		return nil
+	}
+	m.client = m.newClient(project.Name, project.Token)
+	m.currentProject = project.Name
+	m.aggregated = false
+	m.State = stateResourceView
+	// Preload all tabs
+	return m.preloadResources()
+}
+
+// getTokenError returns why the token of a project couldn't be read from its backend
+func (m Model) getTokenError(project config.ProjectConfig) error {
+	if err := m.tokenErrors[project.Name]; err != nil {
+		return fmt.Errorf("reading the token of %s from the %s backend failed: %w", project.Name, project.GetTokenBackend(), err)
+	}
+	if project.Token == "" {
+		return fmt.Errorf("the token of %s isn't available", project.Name)
+	}
+	return nil
+}
+
+// storeProjectToken moves the token of a project added in the app into the vault, if the vault is in use
+func (m *Model) storeProjectToken(name string) error {
+	if m.vault == nil {
+		return nil
+	}
+	delete(m.tokenErrors, name)
+	return m.config.MoveToken(context.Background(), name, config.TokenBackendVault, m.vault)
+}
+
+// getReplacedTokenWarning tells when the new token of a re-added project isn't kept where the old one was.
+// Tokens of vault projects are replaced in the vault if it is unlocked, commands can't be written to.
+func (m *Model) getReplacedTokenWarning(replaced *config.ProjectConfig) string {
+	if replaced == nil {
+		return ""
+	}
+	switch replaced.GetTokenBackend() {
+	case config.TokenBackendVault:
+		if m.vault == nil {
+			return fmt.Sprintf("⚠️  The vault is locked, so the new token of %s is kept in plaintext and the old one stays in the vault", replaced.Name)
+		}
+	case config.TokenBackendCommand:
+		where := "in plaintext in config.json"
+		if m.vault != nil {
+			where = "in the vault"
+		}
+		return fmt.Sprintf("⚠️  The token of %s was read with %q, the new token is kept %s instead", replaced.Name, replaced.TokenRef, where)
+	}
+	return ""
+}
+
+// deleteProjectToken removes the token of a deleted project from the vault
+func (m *Model) deleteProjectToken(project config.ProjectConfig) error {
+	if m.vault == nil || project.GetTokenBackend() != config.TokenBackendVault {
+		return nil
+	}
+	return m.vault.Delete(project.TokenRef)
+}
+
+// saveConfigWithWarning saves the config, then shows a warning in place of the saved message
+func saveConfigWithWarning(cfg *config.Config, warning string) tea.Cmd {
+	return tea.Sequence(config.SaveConfigCmd(cfg), func() tea.Msg {
+		return message.StatusMsg(warning)
+	})
+}
+
+func (m Model) renderTokenUnlock() string {
+	if !m.config.UsesTokenBackend(config.TokenBackendVault) {
+		return fmt.Sprintf(
+			"\n%s\n\n%s\n",
+			titleStyle.Render("lazyhetzner"),
+			infoStyle.Render("🔑 Reading API tokens..."),
+		)
+	}
+
+	path, err := config.GetVaultPath()
+	if err != nil {
+		path = "the lazyhetzner config directory"
+	}
+	statusView := ""
+	switch {
+	case m.resolvingTokens:
+		statusView = "\n\n" + infoStyle.Render("🔑 Unlocking the vault and reading API tokens...")
+	case errors.Is(m.unlockErr, secret.ErrWrongPassphrase):
+		statusView = "\n\n" + errorStyle.Render("⚠️  Wrong passphrase, please try again")
+	case m.unlockErr != nil:
+		statusView = "\n\n" + errorStyle.Render("⚠️  "+m.unlockErr.Error())
+	}
+	return fmt.Sprintf(
+		"\n%s\n\n%s\n\n%s%s\n\n%s\n",
+		titleStyle.Render("lazyhetzner - Unlock Tokens"),
+		infoStyle.Render("🔒 Enter the passphrase of the token vault at "+path+":"),
+		m.passphraseInput.View(),
+		statusView,
+		helpStyle.Render("Enter: unlock • ctrl+s: skip, leaving the vault's projects unavailable • Esc: quit"),
+	)
+}
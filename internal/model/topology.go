@@ -0,0 +1,153 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/grammeaway/lazyhetzner/internal/resource"
+	"github.com/grammeaway/lazyhetzner/internal/topology"
+)
+
+// Lines taken by the breadcrumbs, title, header and help of the topology view
+const topologyChromeHeight = 8
+
+// openTopology shows how the loaded resources of the project are wired together
+func (m *Model) openTopology() tea.Cmd {
+	if m.aggregated {
+		m.statusMessage = "⚠️  The topology is shown per project, open a single project to see it"
+		return clearStatusMessage()
+	}
+	return m.navigate(page{State: stateTopologyView, Tab: m.activeTab})
+}
+
+func (m Model) getTopologyRows() []topology.Row {
+	return topology.Flatten(topology.Build(m.getRelatedItems), m.topologyCollapsed)
+}
+
+func (m Model) getTopologyBodyHeight() int {
+	return max(5, m.height-topologyChromeHeight)
+}
+
+func (m *Model) moveTopologyCursor(offset int) {
+	rows := m.getTopologyRows()
+	if len(rows) == 0 {
+		return
+	}
+	m.topologyCursor = max(0, min(len(rows)-1, m.topologyCursor+offset))
+	visible := m.getTopologyBodyHeight()
+	if m.topologyCursor < m.topologyScroll {
+		m.topologyScroll = m.topologyCursor
+	} else if m.topologyCursor >= m.topologyScroll+visible {
+		m.topologyScroll = m.topologyCursor - visible + 1
+	}
+}
+
+// setTopologyCollapsed collapses or expands the selected node. Collapsing a node without
+// children, or one that already is collapsed, moves the cursor to its parent instead.
+func (m *Model) setTopologyCollapsed(collapse bool) {
+	rows := m.getTopologyRows()
+	if m.topologyCursor >= len(rows) {
+		return
+	}
+	row := rows[m.topologyCursor]
+	if collapse && (row.Collapsed || len(row.Node.Children) == 0) {
+		parentKey := row.Key[:max(0, strings.LastIndex(row.Key, "/"))]
+		for i, parent := range rows[:m.topologyCursor] {
+			if parent.Key == parentKey {
+				m.moveTopologyCursor(i - m.topologyCursor)
+				return
+			}
+		}
+		return
+	}
+	if m.topologyCollapsed == nil {
+		m.topologyCollapsed = make(map[string]bool)
+	}
+	if collapse {
+		m.topologyCollapsed[row.Key] = true
+	} else {
+		delete(m.topologyCollapsed, row.Key)
+	}
+}
+
+// openTopologyNode opens the resource of the selected node. Groups are collapsed or expanded instead.
+func (m *Model) openTopologyNode() tea.Cmd {
+	rows := m.getTopologyRows()
+	if m.topologyCursor >= len(rows) {
+		return nil
+	}
+	row := rows[m.topologyCursor]
+	if row.Node.IsGroup() {
+		m.setTopologyCollapsed(!row.Collapsed)
+		return nil
+	}
+	return m.openLink(row.Node.Link)
+}
+
+// getTopologyNodeStyle colours nodes by the type of resource they show
+func getTopologyNodeStyle(node *topology.Node) lipgloss.Style {
+	if node.IsGroup() {
+		return detailTitleStyle
+	}
+	switch node.Link.ResourceType {
+	case resource.ResourceNetworks:
+		if node.Link.Part != "" {
+			return topologySubnetStyle
+		}
+		return topologyNetworkStyle
+	case resource.ResourceLoadBalancers:
+		return topologyLoadBalancerStyle
+	case resource.ResourceServers:
+		return topologyServerStyle
+	default:
+		return topologyAttachmentStyle
+	}
+}
+
+func (m Model) renderTopology() string {
+	var topologyView strings.Builder
+	topologyView.WriteString(m.renderBreadcrumbs() + "\n\n")
+	topologyView.WriteString(fmt.Sprintf("%s\n\n", titleStyle.Render("Project Topology")))
+	topologyView.WriteString(infoStyle.Render(fmt.Sprintf("🗺️  How the resources of %s are wired together", m.currentProject)) + "\n\n")
+
+	rows := m.getTopologyRows()
+	if len(rows) == 0 {
+		topologyView.WriteString(noDetailsStyle.Render("⚠️  No resources loaded for this project") + "\n")
+	}
+	visible := m.getTopologyBodyHeight()
+	start := min(m.topologyScroll, max(0, len(rows)-visible))
+	end := min(len(rows), start+visible)
+	for i := start; i < end; i++ {
+		row := rows[i]
+		toggle := "  "
+		if len(row.Node.Children) > 0 {
+			toggle = "▾ "
+			if row.Collapsed {
+				toggle = "▸ "
+			}
+		}
+		label := row.Node.Label
+		if row.Collapsed {
+			label += fmt.Sprintf(" (+%d)", len(row.Node.Children))
+		}
+		if i == m.topologyCursor {
+			label = selectedMenuStyle.Render(label)
+		} else {
+			label = getTopologyNodeStyle(row.Node).Render(label)
+		}
+		line := helpStyle.Render(row.Prefix) + toggle + label
+		topologyView.WriteString(lipgloss.NewStyle().MaxWidth(max(0, m.width-2)).Render(line) + "\n")
+	}
+
+	helpText := "💡 ↑/↓: navigate • Enter: open resource • ←/→: collapse/expand • q/[: back • ]: forward"
+	if len(rows) > visible {
+		helpText += fmt.Sprintf(" • lines %d-%d of %d", start+1, end, len(rows))
+	}
+	if m.statusMessage != "" {
+		topologyView.WriteString(warningStyle.Render(m.statusMessage) + "\n")
+	}
+	topologyView.WriteString(helpStyle.Render(helpText))
+	return topologyView.String()
+}
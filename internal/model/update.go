@@ -3,28 +3,19 @@ package model
 import (
 	"fmt"
 	"github.com/charmbracelet/bubbles/key"
-	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/grammeaway/lazyhetzner/internal/audit"
+	"github.com/grammeaway/lazyhetzner/internal/bulk"
 	"github.com/grammeaway/lazyhetzner/internal/config"
-	ctm_fw "github.com/grammeaway/lazyhetzner/internal/context_menu/firewall"
-	ctm_fip "github.com/grammeaway/lazyhetzner/internal/context_menu/floatingip"
-	ctm_lb "github.com/grammeaway/lazyhetzner/internal/context_menu/loadbalancer"
-	ctm_n "github.com/grammeaway/lazyhetzner/internal/context_menu/network"
-	ctm_serv "github.com/grammeaway/lazyhetzner/internal/context_menu/server"
-	ctm_vol "github.com/grammeaway/lazyhetzner/internal/context_menu/volume"
+	"github.com/grammeaway/lazyhetzner/internal/cost"
 	"github.com/grammeaway/lazyhetzner/internal/input_form/project"
+	"github.com/grammeaway/lazyhetzner/internal/iplookup"
 	"github.com/grammeaway/lazyhetzner/internal/message"
+	"github.com/grammeaway/lazyhetzner/internal/metrics"
 	"github.com/grammeaway/lazyhetzner/internal/resource"
-	r_fw "github.com/grammeaway/lazyhetzner/internal/resource/firewall"
-	r_fip "github.com/grammeaway/lazyhetzner/internal/resource/floatingip"
 	r_label "github.com/grammeaway/lazyhetzner/internal/resource/label"
-	r_lb "github.com/grammeaway/lazyhetzner/internal/resource/loadbalancer"
-	r_n "github.com/grammeaway/lazyhetzner/internal/resource/network"
 	r_prj "github.com/grammeaway/lazyhetzner/internal/resource/project"
-	r_serv "github.com/grammeaway/lazyhetzner/internal/resource/server"
-	r_vol "github.com/grammeaway/lazyhetzner/internal/resource/volume"
 	util "github.com/grammeaway/lazyhetzner/utility"
-	"github.com/hetznercloud/hcloud-go/v2/hcloud"
 	"strings"
 )
 
@@ -35,12 +26,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.height = msg.Height
 
 		// Update list sizes
-		for rt := range m.Lists {
-			l := m.Lists[rt]
-			l.SetWidth(msg.Width - 4)
-			l.SetHeight(msg.Height - 10)
-			m.Lists[rt] = l
-		}
+		m.resizeLists()
 
 		if m.config != nil {
 			m.updateProjectList()
@@ -57,25 +43,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.updateProjectList()
 		m.TerminalInput.SetValue(m.config.DefaultTerminal)
 
-		// If there's a default project, set it up but don't load resources yet
-		if m.config.DefaultProject != "" {
-			project := m.config.GetProject(m.config.DefaultProject)
-			if project != nil {
-				m.client = hcloud.NewClient(hcloud.WithToken(project.Token))
-				m.currentProject = project.Name
-				m.State = stateResourceView
-				// Load the first tab's resources
-				return m, tea.Batch(
-					resource.StartResourceLoad(m.activeTab),
-					m.getResourceLoadCmd(m.activeTab),
-				)
-			}
-		}
+		// Tokens kept outside of config.json are read before the default project is opened
+		return m, m.resolveTokens()
 
-		// If no projects, go to token input
-		if len(m.config.Projects) == 0 {
-			m.State = StateProjectSelect
-		}
+	case config.TokensResolvedMsg:
+		return m, m.handleTokensResolved(msg)
 	case tea.KeyMsg:
 		if key.Matches(msg, keys.Exit) {
 			// Handle exit key - quit the application
@@ -87,6 +59,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case StateProjectSelect:
 				// Only quit the entire application from project select
 				return m, tea.Quit
+			case stateTokenUnlock:
+				// 'q' may be part of the passphrase, so only esc quits
+				if msg.String() == "esc" {
+					return m, tea.Quit
+				}
 			case stateTokenInput:
 				// From token input, go back to project select if projects exist, otherwise quit
 				if len(m.config.Projects) > 0 {
@@ -99,13 +76,23 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.State = stateResourceView
 				m.err = nil // Clear error
 				return m, nil
-			case stateLabelView:
-				// From label view, go back to resource view
-				m.State = stateResourceView
-				return m, nil
+			case stateLabelView, stateDetailView, stateTopologyView, stateCostView:
+				// Sub-views go back to the view they were opened from
+				return m, m.goBack()
+			case stateAuditView:
+				// While the resource filter is focused, 'q' is typed into it and esc leaves it
+				if !m.auditFilterInput.Focused() {
+					return m, m.goBack()
+				}
+				if msg.String() == "esc" {
+					m.auditFilterInput.Blur()
+					return m, nil
+				}
 			case stateResourceView:
-				// From resource view, go back to project select
+				// From resource view, go back to project select, cancelling the project's pending requests
 				m.State = StateProjectSelect
+				m.endSession()
+				m.clearNavigation()
 				return m, nil
 			case stateProjectManage:
 				// From project manage, go back to project select
@@ -118,24 +105,39 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				// From context menu, go back to resource view
 				m.State = stateResourceView
 				return m, nil
-			case stateLoadBalancerTargetView:
-				// From load balancer target view, go back to resource view
-				m.State = stateResourceView
-				return m, nil
-			case stateLoadBalancerServiceView:
-				// From load balancer service view, go back to resource view
-				m.State = stateResourceView
-				return m, nil
-			case stateFirewallRuleView:
-				m.State = stateResourceView
-				return m, nil
-			case stateNetworkSubnetView:
-				m.State = stateResourceView
-				return m, nil
-			case stateServerDetailView:
+			case stateBulkMenu, stateBulkResultView:
 				m.State = stateResourceView
 				return m, nil
-
+			case stateBulkInput:
+				// Only esc cancels here, so 'q' can still be typed into the input
+				if msg.String() == "esc" {
+					m.bulkInput.Blur()
+					m.State = stateResourceView
+					return m, nil
+				}
+			case stateConfirmInput:
+				if msg.String() == "esc" {
+					m.cancelConfirmation()
+					return m, nil
+				}
+			case stateIPLookup:
+				if msg.String() == "esc" {
+					m.ipLookupInput.Blur()
+					m.State = stateResourceView
+					return m, nil
+				}
+			case stateSearch:
+				if msg.String() == "esc" {
+					m.searchInput.Blur()
+					m.State = stateResourceView
+					return m, nil
+				}
+			case stateLabelSelectorInput:
+				if msg.String() == "esc" {
+					m.labelSelectorInput.Blur()
+					m.State = stateResourceView
+					return m, nil
+				}
 			}
 		}
 
@@ -146,18 +148,24 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case key.Matches(msg, keys.Enter):
 				if selectedItem := m.projectList.SelectedItem(); selectedItem != nil {
 					if projectItem, ok := selectedItem.(r_prj.ProjectItem); ok {
-						m.client = hcloud.NewClient(hcloud.WithToken(projectItem.Config.Token))
+						if err := m.getTokenError(projectItem.Config); err != nil {
+							m.statusMessage = "⚠️  " + err.Error()
+							return m, clearStatusMessage()
+						}
+						m.client = m.newClient(projectItem.Config.Name, projectItem.Config.Token)
 						m.currentProject = projectItem.Config.Name
+						m.aggregated = false
 						m.State = stateResourceView
-						// Reset loaded resources for new project
-						m.LoadedResources = make(map[resource.ResourceType]bool)
-						// Load the first tab's resources
-						return m, tea.Batch(
-							resource.StartResourceLoad(m.activeTab),
-							m.getResourceLoadCmd(m.activeTab),
-						)
+						// Reset loaded resources and marks for new project
+						m.resetResources()
+						// Preload all tabs
+						return m, m.preloadResources()
 					}
 				}
+			case key.Matches(msg, keys.AllProjects):
+				if len(m.config.Projects) > 0 {
+					return m, m.enterAggregatedView()
+				}
 			case key.Matches(msg, keys.Add):
 				m.projectForm = project.NewProjectForm()
 				m.State = stateProjectManage
@@ -168,6 +176,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					if projectItem, ok := selectedItem.(r_prj.ProjectItem); ok {
 						m.config.RemoveProject(projectItem.Config.Name)
 						m.updateProjectList()
+						if err := m.deleteProjectToken(projectItem.Config); err != nil {
+							return m, saveConfigWithWarning(m.config, fmt.Sprintf("⚠️  Removing the token from the vault failed: %v", err))
+						}
 						return m, config.SaveConfigCmd(m.config)
 					}
 				}
@@ -204,9 +215,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				token := strings.TrimSpace(m.projectForm.Inputs[1].Value())
 
 				if name != "" && token != "" {
-					m.config.AddProject(name, token)
+					replaced := m.config.AddProject(name, token)
 					m.updateProjectList()
 					m.State = StateProjectSelect
+					if err := m.storeProjectToken(name); err != nil {
+						return m, saveConfigWithWarning(m.config, fmt.Sprintf("⚠️  Storing the token in the vault failed, so it is kept in plaintext: %v", err))
+					}
+					if warning := m.getReplacedTokenWarning(replaced); warning != "" {
+						return m, saveConfigWithWarning(m.config, warning)
+					}
 					return m, config.SaveConfigCmd(m.config)
 				}
 			}
@@ -220,6 +237,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, config.SaveConfigCmd(m.config)
 			}
 
+		case stateTokenUnlock:
+			switch {
+			case key.Matches(msg, keys.Enter):
+				return m, m.submitPassphrase()
+			case key.Matches(msg, keys.SkipVault):
+				return m, m.skipVault()
+			}
+
 		case stateTokenInput:
 			switch {
 			case key.Matches(msg, keys.Enter):
@@ -228,138 +253,215 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					return m, nil
 				}
 
-				m.client = hcloud.NewClient(hcloud.WithToken(token))
+				m.client = m.newClient("", token)
+				m.currentProject = ""
+				m.aggregated = false
 				m.State = stateResourceView
-				// Reset loaded resources
-				m.LoadedResources = make(map[resource.ResourceType]bool)
-				// Load the first tab's resources
-				return m, tea.Batch(
-					resource.StartResourceLoad(m.activeTab),
-					m.getResourceLoadCmd(m.activeTab),
-				)
+				// Reset loaded resources and marks
+				m.resetResources()
+				// Preload all tabs
+				return m, m.preloadResources()
 			}
 
 		case stateResourceView:
 			switch {
 			case key.Matches(msg, keys.Enter):
-				// Show context menu for servers
-				switch m.activeTab {
-				case resource.ResourceServers:
-					if currentList, exists := m.Lists[resource.ResourceServers]; exists {
-						if selectedItem := currentList.SelectedItem(); selectedItem != nil {
-							if serverItem, ok := selectedItem.(r_serv.ServerItem); ok {
-								m.contextMenu = ctm_serv.CreateServerContextMenu(serverItem.Server)
-								m.State = stateContextMenu
-							}
-						}
-					}
-				case resource.ResourceNetworks:
-					if currentList, exists := m.Lists[resource.ResourceNetworks]; exists {
-						if selectedItem := currentList.SelectedItem(); selectedItem != nil {
-							if networkItem, ok := selectedItem.(r_n.NetworkItem); ok {
-								m.contextMenu = ctm_n.CreateNetworkContextMenu(networkItem.Network)
-								m.State = stateContextMenu
-							}
-						}
-					}
-
-				case resource.ResourceLoadBalancers:
-					if currentList, exists := m.Lists[resource.ResourceLoadBalancers]; exists {
-						if selectedItem := currentList.SelectedItem(); selectedItem != nil {
-							if lbItem, ok := selectedItem.(r_lb.LoadBalancerItem); ok {
-								m.contextMenu = ctm_lb.CreateLoadbalancerContextMenu(lbItem.Lb)
-								m.State = stateContextMenu
-							}
-						}
-					}
-				case resource.ResourceFloatingIPs:
-					if currentList, exists := m.Lists[resource.ResourceFloatingIPs]; exists {
-						if selectedItem := currentList.SelectedItem(); selectedItem != nil {
-							if floatingIPItem, ok := selectedItem.(r_fip.FloatingIPItem); ok {
-								m.contextMenu = ctm_fip.CreateFloatingIPContextMenu(floatingIPItem.FloatingIP)
-								m.State = stateContextMenu
-							}
-						}
-					}
-				case resource.ResourceVolumes:
-					if currentList, exists := m.Lists[resource.ResourceVolumes]; exists {
-						if selectedItem := currentList.SelectedItem(); selectedItem != nil {
-							if volumeItem, ok := selectedItem.(r_vol.VolumeItem); ok {
-								m.contextMenu = ctm_vol.CreateVolumeContextMenu(volumeItem.Volume)
-								m.State = stateContextMenu
-							}
-						}
-					}
-				case resource.ResourceFirewalls:
-					if currentList, exists := m.Lists[resource.ResourceFirewalls]; exists {
-						if selectedItem := currentList.SelectedItem(); selectedItem != nil {
-							if firewallItem, ok := selectedItem.(r_fw.FirewallItem); ok {
-								m.contextMenu = ctm_fw.CreateFirewallContextMenu(firewallItem.Firewall)
-								m.State = stateContextMenu
-							}
-						}
-					}
+				// Show the context menu of the selected resource
+				if menu, ok := m.getSelectedContextMenu(); ok {
+					m.contextMenu = menu
+					m.State = stateContextMenu
 				}
-			case key.Matches(msg, keys.Details):
-				if m.activeTab == resource.ResourceServers {
-					if currentList, exists := m.Lists[resource.ResourceServers]; exists {
-						if selectedItem := currentList.SelectedItem(); selectedItem != nil {
-							if serverItem, ok := selectedItem.(r_serv.ServerItem); ok {
-								return m, r_serv.LoadServerDetails(m.client, serverItem.Server.ID)
-							}
-						}
-					}
+			case key.Matches(msg, keys.Mark) && !m.isFiltering():
+				m.toggleMarkSelected()
+				return m, nil
+			case key.Matches(msg, keys.MarkAll) && !m.isFiltering():
+				m.toggleMarkVisible()
+				return m, nil
+			case key.Matches(msg, keys.BulkActions) && !m.isFiltering():
+				m.openBulkMenu()
+				if m.State != stateBulkMenu {
+					return m, clearStatusMessage()
+				}
+				return m, nil
+			case key.Matches(msg, keys.Search) && !m.isFiltering():
+				if m.hasClient() {
+					return m, m.openSearch()
+				}
+			case key.Matches(msg, keys.IPLookup) && !m.isFiltering():
+				if m.hasClient() {
+					return m, m.openIPLookup()
+				}
+			case key.Matches(msg, keys.LabelSelector) && !m.isFiltering():
+				if m.hasClient() {
+					return m, m.openLabelSelectorInput()
 				}
+			case key.Matches(msg, keys.AutoRefresh) && !m.isFiltering():
+				return m, m.cycleAutoRefresh()
+			case key.Matches(msg, keys.CostEstimate) && !m.isFiltering():
+				return m, m.openCostEstimate()
+			case key.Matches(msg, keys.Topology) && !m.isFiltering():
+				return m, m.openTopology()
+			case key.Matches(msg, keys.AuditLog) && !m.isFiltering():
+				return m, m.openAuditLog()
+			case key.Matches(msg, keys.Back) && !m.isFiltering():
+				return m, m.goBack()
+			case key.Matches(msg, keys.Forward) && !m.isFiltering():
+				return m, m.goForward()
+			case key.Matches(msg, keys.SortOrder) && !m.isFiltering():
+				return m, m.cycleSortOrder()
+			case key.Matches(msg, keys.Preview) && !m.isFiltering():
+				return m, m.togglePreview()
+			case key.Matches(msg, keys.TableMode) && !m.isFiltering():
+				return m, m.toggleTableMode()
+			case m.isTableMode(m.activeTab) && !m.isFiltering() && key.Matches(msg, keys.ColumnLeft):
+				m.moveColumnFocus(-1)
+				return m, nil
+			case m.isTableMode(m.activeTab) && !m.isFiltering() && key.Matches(msg, keys.ColumnRight):
+				m.moveColumnFocus(1)
+				return m, nil
+			case m.isTableMode(m.activeTab) && !m.isFiltering() && key.Matches(msg, keys.SortColumn):
+				return m, m.sortByFocusedColumn()
+			case m.isTableMode(m.activeTab) && !m.isFiltering() && key.Matches(msg, keys.WidenColumn):
+				return m, m.resizeFocusedColumn(2)
+			case m.isTableMode(m.activeTab) && !m.isFiltering() && key.Matches(msg, keys.NarrowColumn):
+				return m, m.resizeFocusedColumn(-2)
+			case m.isTableMode(m.activeTab) && !m.isFiltering() && key.Matches(msg, keys.HideColumn):
+				return m, m.hideFocusedColumn()
+			case m.isTableMode(m.activeTab) && !m.isFiltering() && key.Matches(msg, keys.ShowColumns):
+				return m, m.showAllColumns()
+			case key.Matches(msg, keys.Details):
+				return m, m.openSelectedDetails()
 
 			case key.Matches(msg, keys.Tab):
-				m.activeTab = (m.activeTab + 1) % resource.ResourceType(len(resourceTabs))
-
-				// Load resources for new tab if not already loaded
-				if !m.LoadedResources[m.activeTab] {
-					return m, tea.Batch(
-						resource.StartResourceLoad(m.activeTab),
-						m.getResourceLoadCmd(m.activeTab),
-					)
+				// Switch tabs, served from the cache if already loaded
+				if next, ok := m.getAdjacentTab(1, true); ok {
+					return m, m.showTab(next)
 				}
 			case key.Matches(msg, keys.Reload):
-				if m.client != nil {
+				if m.hasClient() {
 					// Mark current resource as not loaded and reload
 					m.LoadedResources[m.activeTab] = false
-					return m, tea.Batch(
-						resource.StartResourceLoad(m.activeTab),
-						m.getResourceLoadCmd(m.activeTab),
-					)
+					return m, m.loadResource(m.activeTab)
 				}
 			case key.Matches(msg, keys.Left):
-				if m.activeTab > 0 {
-					m.activeTab--
-
-					// Load resources for new tab if not already loaded
-					if !m.LoadedResources[m.activeTab] {
-						return m, tea.Batch(
-							resource.StartResourceLoad(m.activeTab),
-							m.getResourceLoadCmd(m.activeTab),
-						)
-					}
+				if previous, ok := m.getAdjacentTab(-1, false); ok {
+					return m, m.showTab(previous)
 				}
 			case key.Matches(msg, keys.Right):
-				if int(m.activeTab) < len(resourceTabs)-1 {
-					m.activeTab++
-
-					// Load resources for new tab if not already loaded
-					if !m.LoadedResources[m.activeTab] {
-						return m, tea.Batch(
-							resource.StartResourceLoad(m.activeTab),
-							m.getResourceLoadCmd(m.activeTab),
-						)
-					}
+				if next, ok := m.getAdjacentTab(1, false); ok {
+					return m, m.showTab(next)
 				}
 			}
 
 		case stateLabelView:
-			// Label view specific keys (quit is handled globally above)
-			// Add any other label view specific key handling here
-			break
+			switch {
+			case key.Matches(msg, keys.Back):
+				return m, m.goBack()
+			case key.Matches(msg, keys.Forward):
+				return m, m.goForward()
+			}
+			return m, nil
+
+		case stateTopologyView:
+			switch {
+			case key.Matches(msg, keys.Up):
+				m.moveTopologyCursor(-1)
+			case key.Matches(msg, keys.Down):
+				m.moveTopologyCursor(1)
+			case key.Matches(msg, keys.PageUp):
+				m.moveTopologyCursor(-m.getTopologyBodyHeight())
+			case key.Matches(msg, keys.PageDown):
+				m.moveTopologyCursor(m.getTopologyBodyHeight())
+			case key.Matches(msg, keys.Left):
+				m.setTopologyCollapsed(true)
+			case key.Matches(msg, keys.Right):
+				m.setTopologyCollapsed(false)
+			case key.Matches(msg, keys.Enter):
+				return m, m.openTopologyNode()
+			case key.Matches(msg, keys.Back):
+				return m, m.goBack()
+			case key.Matches(msg, keys.Forward):
+				return m, m.goForward()
+			}
+			return m, nil
+
+		case stateCostView:
+			switch {
+			case key.Matches(msg, keys.Up):
+				m.scrollCostView(-1)
+			case key.Matches(msg, keys.Down):
+				m.scrollCostView(1)
+			case key.Matches(msg, keys.PageUp):
+				m.scrollCostView(-m.getCostBodyHeight())
+			case key.Matches(msg, keys.PageDown):
+				m.scrollCostView(m.getCostBodyHeight())
+			case key.Matches(msg, keys.Left):
+				m.cycleCostLabelKey(-1)
+			case key.Matches(msg, keys.Right):
+				m.cycleCostLabelKey(1)
+			case key.Matches(msg, keys.Export):
+				return m, m.exportCostEstimate()
+			case key.Matches(msg, keys.Reload):
+				return m, m.loadCostEstimate()
+			case key.Matches(msg, keys.Back):
+				return m, m.goBack()
+			case key.Matches(msg, keys.Forward):
+				return m, m.goForward()
+			}
+			return m, nil
+
+		case stateAuditView:
+			if m.auditFilterInput.Focused() {
+				if key.Matches(msg, keys.Enter) {
+					m.auditFilterInput.Blur()
+					return m, nil
+				}
+				return m, m.updateAuditFilter(msg)
+			}
+			switch {
+			case key.Matches(msg, keys.Up):
+				m.scrollAuditLog(-1)
+			case key.Matches(msg, keys.Down):
+				m.scrollAuditLog(1)
+			case key.Matches(msg, keys.PageUp):
+				m.scrollAuditLog(-m.getAuditBodyHeight())
+			case key.Matches(msg, keys.PageDown):
+				m.scrollAuditLog(m.getAuditBodyHeight())
+			case key.Matches(msg, keys.Left):
+				m.cycleAuditProject(-1)
+			case key.Matches(msg, keys.Right):
+				m.cycleAuditProject(1)
+			case key.Matches(msg, keys.AuditFilter):
+				return m, m.focusAuditFilter()
+			case key.Matches(msg, keys.Reload):
+				return m, m.reloadAuditLog()
+			case key.Matches(msg, keys.Back):
+				return m, m.goBack()
+			case key.Matches(msg, keys.Forward):
+				return m, m.goForward()
+			}
+			return m, nil
+
+		case stateDetailView:
+			switch {
+			case key.Matches(msg, keys.Up):
+				m.moveDetailCursor(-1)
+			case key.Matches(msg, keys.Down):
+				m.moveDetailCursor(1)
+			case key.Matches(msg, keys.PageUp):
+				m.scrollDetail(-m.getDetailBodyHeight())
+			case key.Matches(msg, keys.PageDown):
+				m.scrollDetail(m.getDetailBodyHeight())
+			case key.Matches(msg, keys.Enter):
+				return m, m.openDetailLink()
+			case key.Matches(msg, keys.TimeRange):
+				return m, m.cycleMetricsWindow()
+			case key.Matches(msg, keys.Back):
+				return m, m.goBack()
+			case key.Matches(msg, keys.Forward):
+				return m, m.goForward()
+			}
+			return m, nil
 
 		case stateContextMenu:
 			switch {
@@ -392,181 +494,167 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			}
 
-		case stateError:
-			// Error state - quit is handled globally above
-			break
-		}
-
-	case config.ProjectSavedMsg:
-		m.statusMessage = "✅ Project configuration saved"
-		return m, clearStatusMessage()
+		case stateBulkMenu:
+			switch {
+			case key.Matches(msg, keys.Up):
+				if m.bulkMenu.SelectedItem > 0 {
+					m.bulkMenu.SelectedItem--
+				}
 
-	case resource.ResourceLoadStartMsg:
-		m.IsLoading = true
-		m.loadingResource = msg.ResourceType
-		return m, nil
+			case key.Matches(msg, keys.Down):
+				if m.bulkMenu.SelectedItem < len(m.bulkMenu.Items)-1 {
+					m.bulkMenu.SelectedItem++
+				}
 
-	case r_serv.ServersLoadedMsg:
-		m.IsLoading = false
-		m.LoadedResources[resource.ResourceServers] = true
-
-		// Create server list
-		serverItems := make([]list.Item, len(msg.Servers))
-		for i, server := range msg.Servers {
-			serverItems[i] = r_serv.ServerItem{
-				Server:       server,
-				ResourceType: resource.ResourceServers,
-				ResourceID:   server.ID,
-			}
-		}
+			case key.Matches(msg, keys.Enter):
+				return m, m.selectBulkAction(m.bulkMenu.Items[m.bulkMenu.SelectedItem].Action)
 
-		serversList := list.New(serverItems, list.NewDefaultDelegate(), m.width-4, m.height-10)
-		serversList.Title = "Servers"
-		m.Lists[resource.ResourceServers] = serversList
-		return m, nil
-	case r_serv.ServerDetailsLoadedMsg:
-		m.IsLoading = false
-		m.serverBeingViewed = msg.Server
-		m.serverDetailNetworks = msg.Networks
-		m.State = stateServerDetailView
-		return m, nil
+			case key.Matches(msg, keys.Num1, keys.Num2, keys.Num3, keys.Num4, keys.Num5,
+				keys.Num6, keys.Num7, keys.Num8, keys.Num9, keys.Num0):
 
-	case r_n.NetworksLoadedMsg:
-		m.IsLoading = false
-		m.LoadedResources[resource.ResourceNetworks] = true
-
-		// Create network list
-		networkItems := make([]list.Item, len(msg.Networks))
-		for i, network := range msg.Networks {
-			networkItems[i] = r_n.NetworkItem{
-				Network:      network,
-				ResourceType: resource.ResourceNetworks,
-				ResourceID:   network.ID,
+				selectedIndex := util.GetIndexFromNumber(msg.String())
+				if selectedIndex >= 0 && selectedIndex < len(m.bulkMenu.Items) {
+					return m, m.selectBulkAction(m.bulkMenu.Items[selectedIndex].Action)
+				}
 			}
-		}
 
-		networksList := list.New(networkItems, list.NewDefaultDelegate(), m.width-4, m.height-10)
-		networksList.Title = "Networks"
-		m.Lists[resource.ResourceNetworks] = networksList
-		return m, nil
+		case stateBulkInput:
+			switch {
+			case key.Matches(msg, keys.Enter):
+				param := strings.TrimSpace(m.bulkInput.Value())
+				if err := bulk.ValidateParam(m.bulkMenu.ResourceType, m.bulkAction, param, len(m.bulkTargets)); err != nil {
+					m.statusMessage = "⚠️  " + err.Error()
+					return m, clearStatusMessage()
+				}
+				m.bulkInput.Blur()
+				return m, m.confirmBulkAction(m.bulkMenu.ResourceType, m.bulkAction, param, m.bulkTargets)
+			}
 
-	case r_lb.LoadBalancersLoadedMsg:
-		m.IsLoading = false
-		m.LoadedResources[resource.ResourceLoadBalancers] = true
-
-		// Create load balancer list
-		lbItems := make([]list.Item, len(msg.LoadBalancers))
-		for i, lb := range msg.LoadBalancers {
-			lbItems[i] = r_lb.LoadBalancerItem{
-				Lb:           lb,
-				ResourceType: resource.ResourceLoadBalancers,
-				ResourceID:   lb.ID,
+		case stateConfirmInput:
+			if key.Matches(msg, keys.Enter) {
+				return m, m.submitConfirmation()
 			}
-		}
 
-		lbList := list.New(lbItems, list.NewDefaultDelegate(), m.width-4, m.height-10)
-		lbList.Title = "Load Balancers"
-		m.Lists[resource.ResourceLoadBalancers] = lbList
-		return m, nil
+		case stateSearch:
+			// Only arrow keys navigate, so letters can still be typed into the input
+			switch msg.String() {
+			case "up", "ctrl+p":
+				if m.searchCursor > 0 {
+					m.searchCursor--
+				}
+				return m, nil
+			case "down", "ctrl+n":
+				if m.searchCursor < len(m.getSearchMatches())-1 {
+					m.searchCursor++
+				}
+				return m, nil
+			case "enter":
+				matches := m.getSearchMatches()
+				if m.searchCursor < len(matches) {
+					m.searchInput.Blur()
+					entry := matches[m.searchCursor].Entry
+					return m, m.jumpToProjectResource(entry.Project, entry.ResourceType, entry.ID)
+				}
+				return m, nil
+			case "tab":
+				return m, m.toggleSearchScope()
+			}
 
-	case r_fw.FirewallsLoadedMsg:
-		m.IsLoading = false
-		m.LoadedResources[resource.ResourceFirewalls] = true
-
-		firewallItems := make([]list.Item, len(msg.Firewalls))
-		for i, firewall := range msg.Firewalls {
-			firewallItems[i] = r_fw.FirewallItem{
-				Firewall:     firewall,
-				ResourceType: resource.ResourceFirewalls,
-				ResourceID:   firewall.ID,
+		case stateIPLookup:
+			switch msg.String() {
+			case "up":
+				if m.ipLookupCursor > 0 {
+					m.ipLookupCursor--
+				}
+				return m, nil
+			case "down":
+				if m.ipLookupResult != nil && m.ipLookupCursor < len(m.ipLookupResult.Owners)-1 {
+					m.ipLookupCursor++
+				}
+				return m, nil
+			case "enter":
+				return m, m.submitIPLookup()
+			case "tab":
+				return m, m.toggleIPLookupScope()
 			}
-		}
 
-		firewallsList := list.New(firewallItems, list.NewDefaultDelegate(), m.width-4, m.height-10)
-		firewallsList.Title = "Firewalls"
-		m.Lists[resource.ResourceFirewalls] = firewallsList
-		return m, nil
+		case stateLabelSelectorInput:
+			switch {
+			case key.Matches(msg, keys.Enter):
+				m.labelSelectorInput.Blur()
+				return m, m.setLabelSelector(strings.TrimSpace(m.labelSelectorInput.Value()))
+			}
 
-	case r_fip.FloatingIPsLoadedMsg:
-		m.IsLoading = false
-		m.LoadedResources[resource.ResourceFloatingIPs] = true
-
-		floatingIPItems := make([]list.Item, len(msg.FloatingIPs))
-		for i, floatingIP := range msg.FloatingIPs {
-			floatingIPItems[i] = r_fip.FloatingIPItem{
-				FloatingIP:   floatingIP,
-				ResourceType: resource.ResourceFloatingIPs,
-				ResourceID:   floatingIP.ID,
+		case stateBulkResultView:
+			switch {
+			case key.Matches(msg, keys.Retry):
+				return m, m.retryBulkAction()
 			}
+
+		case stateError:
+			// Error state - quit is handled globally above
+			break
 		}
 
-		floatingIPsList := list.New(floatingIPItems, list.NewDefaultDelegate(), m.width-4, m.height-10)
-		floatingIPsList.Title = "Floating IPs"
-		m.Lists[resource.ResourceFloatingIPs] = floatingIPsList
+	case config.ProjectSavedMsg:
+		m.statusMessage = "✅ Project configuration saved"
+		return m, clearStatusMessage()
+
+	case resource.ResourceLoadStartMsg:
+		m.IsLoading = true
+		m.loadingResource = msg.ResourceType
 		return m, nil
 
-	case r_vol.VolumesLoadedMsg:
-		m.IsLoading = false
-		m.LoadedResources[resource.ResourceVolumes] = true
+	case resourceLoadedMsg:
+		return m, m.handleResourceLoaded(msg)
 
-		// Create volume list
-		volumeItems := make([]list.Item, len(msg.Volumes))
-		for i, volume := range msg.Volumes {
-			volumeItems[i] = r_vol.VolumeItem{Volume: volume}
-		}
+	case autoRefreshTickMsg:
+		return m, m.handleAutoRefreshTick(msg)
 
-		volumesList := list.New(volumeItems, list.NewDefaultDelegate(), m.width-4, m.height-10)
-		volumesList.Title = "Volumes"
-		m.Lists[resource.ResourceVolumes] = volumesList
+	case clearChangesMsg:
+		m.clearChanges(msg.ResourceType, msg.Generation)
 		return m, nil
 
 	case message.ClipboardCopiedMsg:
 		m.statusMessage = fmt.Sprintf("✅ Copied %s to clipboard", string(msg))
 		return m, clearStatusMessage()
 
-	case ctm_serv.SshLaunchedMsg:
-		m.statusMessage = "🚀 SSH session launched"
-		return m, clearStatusMessage()
-	case ctm_serv.TmuxSSHLaunchedMsg:
-		m.statusMessage = "🪟 SSH session launched in tmux"
-		return m, clearStatusMessage()
-
-	case ctm_serv.ZellijSSHLaunchedMsg:
-		m.statusMessage = "📱 SSH session launched in zellij"
-		return m, clearStatusMessage()
-
 	case r_label.LabelsLoadedMsg:
 		m.IsLoading = false
-		m.loadedLabels = msg.Labels
-		m.labelsPertainingToResource = msg.RelatedResourceName
-		m.State = stateLabelView
-		return m, nil
-
-	case r_lb.ViewLoadbalancerTargetsMsg:
-		m.IsLoading = false
-		m.loadbalancerBeingViewed = msg.LoadBalancer
-		m.loadbalancerTargets = msg.Targets
-		m.State = stateLoadBalancerTargetView
-		return m, nil
+		return m, m.navigate(page{State: stateLabelView, Tab: m.activeTab, Labels: msg.Labels, LabelsOf: msg.RelatedResourceName})
+
+	case bulk.BulkActionCompletedMsg:
+		m.bulkResults = &msg
+		if !m.ownsBulkResults(msg) {
+			// The run was started in another project, so its results don't replace the current view
+			m.statusMessage = fmt.Sprintf("✅ %s finished in another project: %d of %d failed", bulk.GetActionLabel(msg.Action), len(msg.Failed()), len(msg.Results))
+			if msg.AuditErr != nil {
+				m.statusMessage = fmt.Sprintf("⚠️  Recording the results in the audit log failed: %v", msg.AuditErr)
+			}
+			return m, clearStatusMessage()
+		}
+		m.statusMessage = ""
+		if msg.AuditErr != nil {
+			m.statusMessage = fmt.Sprintf("⚠️  Recording the results in the audit log failed: %v", msg.AuditErr)
+		}
+		m.State = stateBulkResultView
+		// Reload the affected tab so the list reflects the changes
+		return m, m.loadResource(msg.ResourceType)
 
-	case r_lb.ViewLoadbalancerServicesMsg:
-		m.IsLoading = false
-		m.loadbalancerBeingViewed = msg.LoadBalancer
-		m.loadbalancerServices = msg.Services
-		m.State = stateLoadBalancerServiceView
+	case crossProjectSearchLoadedMsg:
+		if m.crossProjectItems == nil {
+			return m, nil
+		}
+		m.crossProjectItems[msg.ResourceType] = msg.Items
+		for project, err := range msg.Errors {
+			m.crossProjectErrors[project] = err
+		}
 		return m, nil
 
-	case r_fw.ViewFirewallRulesMsg:
-		m.IsLoading = false
-		m.firewallBeingViewed = msg.Firewall
-		m.firewallRules = msg.Rules
-		m.State = stateFirewallRuleView
-		return m, nil
-	case r_n.ViewNetworkSubnetsMsg:
-		m.IsLoading = false
-		m.networkBeingViewed = msg.Network
-		m.networkSubnets = msg.Subnets
-		m.State = stateNetworkSubnetView
+	case iplookup.IPLookupResultMsg:
+		m.ipLookupResult = &msg
+		m.ipLookupCursor = 0
+		m.statusMessage = ""
 		return m, nil
 
 	case message.CancelCtxMenuMsg:
@@ -578,12 +666,44 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.statusMessage = string(msg)
 		return m, clearStatusMessage()
 
+	case metrics.LoadedMsg:
+		m.handleMetricsLoaded(msg)
+		return m, nil
+
+	case metricsTickMsg:
+		return m, m.handleMetricsTick(msg)
+
+	case audit.LoadedMsg:
+		m.auditEntries, m.auditErr, m.auditLoading = msg.Entries, msg.Err, false
+		m.scrollAuditLog(0)
+		return m, nil
+
+	case cost.EstimateLoadedMsg:
+		m.costEstimate = &msg.Estimate
+		m.costScroll = 0
+		m.statusMessage = ""
+		return m, nil
+
+	case cost.ExportedMsg:
+		if msg.Err != nil {
+			m.statusMessage = fmt.Sprintf("⚠️  Exporting the cost estimate failed: %v", msg.Err)
+		} else {
+			m.statusMessage = "✅ Exported the cost estimate to " + msg.Path
+		}
+		return m, clearStatusMessage()
+
 	case message.ErrorMsg:
 		m.State = stateError
 		m.err = msg.Err
 		return m, nil
 	}
 
+	// Detail views of every resource type
+	if detail, ok := getDetail(msg); ok {
+		m.IsLoading = false
+		return m, m.showDetail(detail)
+	}
+
 	// Update components
 	if m.State == stateTokenInput {
 		var cmd tea.Cmd
@@ -591,6 +711,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, cmd
 	}
 
+	if m.State == stateTokenUnlock && !m.resolvingTokens {
+		var cmd tea.Cmd
+		m.passphraseInput, cmd = m.passphraseInput.Update(msg)
+		return m, cmd
+	}
+
 	if m.State == StateProjectSelect && m.config != nil {
 		var cmd tea.Cmd
 		m.projectList, cmd = m.projectList.Update(msg)
@@ -603,6 +729,40 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, cmd
 	}
 
+	if m.State == stateBulkInput {
+		var cmd tea.Cmd
+		m.bulkInput, cmd = m.bulkInput.Update(msg)
+		return m, cmd
+	}
+
+	if m.State == stateSearch {
+		var cmd tea.Cmd
+		previousQuery := m.searchInput.Value()
+		m.searchInput, cmd = m.searchInput.Update(msg)
+		if m.searchInput.Value() != previousQuery {
+			m.searchCursor = 0
+		}
+		return m, cmd
+	}
+
+	if m.State == stateIPLookup {
+		var cmd tea.Cmd
+		m.ipLookupInput, cmd = m.ipLookupInput.Update(msg)
+		return m, cmd
+	}
+
+	if m.State == stateLabelSelectorInput {
+		var cmd tea.Cmd
+		m.labelSelectorInput, cmd = m.labelSelectorInput.Update(msg)
+		return m, cmd
+	}
+
+	if m.State == stateConfirmInput {
+		var cmd tea.Cmd
+		m.confirmInput, cmd = m.confirmInput.Update(msg)
+		return m, cmd
+	}
+
 	if m.State == stateTerminalConfig {
 		var cmd tea.Cmd
 		m.TerminalInput, cmd = m.TerminalInput.Update(msg)
@@ -3,16 +3,25 @@ package model
 import (
 	"fmt"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/grammeaway/lazyhetzner/internal/bulk"
+	ctm "github.com/grammeaway/lazyhetzner/internal/context_menu"
 	"github.com/grammeaway/lazyhetzner/internal/resource"
 	util "github.com/grammeaway/lazyhetzner/utility"
-	"github.com/hetznercloud/hcloud-go/v2/hcloud"
 	"github.com/mattn/go-runewidth"
-	"net"
-	"sort"
 	"strings"
 )
 
 func (m Model) View() string {
+	banner := m.renderProtectedBanner()
+	if banner == "" {
+		return m.renderState()
+	}
+	// The banner takes a line from every view
+	m.height--
+	return banner + "\n" + m.renderState()
+}
+
+func (m Model) renderState() string {
 	switch m.State {
 	case StateProjectSelect:
 		if m.config == nil {
@@ -43,7 +52,7 @@ func (m Model) View() string {
 			titleStyle.Render("lazyhetzner"),
 			m.projectList.View(),
 			statusView,
-			helpStyle.Render("Enter: select project • a: add project • d: delete project • p: set default project • t: set default terminal • q: quit"),
+			helpStyle.Render("Enter: select project • m: all projects • a: add project • d: delete project • p: set default project • t: set default terminal • q: quit"),
 		)
 
 	case stateProjectManage:
@@ -91,6 +100,9 @@ func (m Model) View() string {
 			helpStyle.Render("Press Enter to continue • Press q to go back"),
 		)
 
+	case stateTokenUnlock:
+		return m.renderTokenUnlock()
+
 	case stateLoading:
 		return fmt.Sprintf(
 			"\n%s\n\n%s\n",
@@ -100,17 +112,15 @@ func (m Model) View() string {
 
 	case stateResourceView:
 		// Project header
-		projectHeader := fmt.Sprintf("Project: %s", m.currentProject)
-		if m.currentProject == "" {
-			projectHeader = "One-time Access"
-		}
+		projectHeader := m.getProjectHeader()
 
 		// Render tabs
 		var tabs []string
-		for i, tab := range resourceTabs {
-			if resource.ResourceType(i) == m.activeTab {
+		for _, rt := range getTabs() {
+			tab := m.getTabTitle(rt)
+			if rt == m.activeTab {
 				// Show loading indicator in active tab if loading
-				if m.IsLoading && m.loadingResource == resource.ResourceType(i) {
+				if m.isLoadingResource(rt) {
 					tabs = append(tabs, titleStyle.Render(tab+" (loading...)"))
 				} else {
 					tabs = append(tabs, titleStyle.Render(tab))
@@ -123,14 +133,18 @@ func (m Model) View() string {
 
 		// Render current list or loading message
 		var listView string
-		if m.IsLoading && m.loadingResource == m.activeTab {
-			listView = infoStyle.Render("Loading " + strings.ToLower(resourceTabs[m.activeTab]) + "...")
-		} else if currentList, exists := m.Lists[m.activeTab]; exists {
-			listView = currentList.View()
+		// Cached resources stay visible while they are reloaded
+		if currentList, exists := m.Lists[m.activeTab]; exists {
+			listView = m.renderWithPreview(m.renderResourceList(m.activeTab, currentList))
+		} else if m.isLoadingResource(m.activeTab) {
+			listView = infoStyle.Render("Loading " + strings.ToLower(resource.GetResourceNameFromType(m.activeTab)) + "...")
 		} else if !m.LoadedResources[m.activeTab] {
 			listView = helpStyle.Render("Resources not loaded yet. Loading will start automatically.")
 		} else {
-			listView = helpStyle.Render("No " + strings.ToLower(resourceTabs[m.activeTab]) + " found.")
+			listView = helpStyle.Render("No " + strings.ToLower(resource.GetResourceNameFromType(m.activeTab)) + " found.")
+			if selector := m.labelSelectors[m.activeTab]; selector != "" {
+				listView = helpStyle.Render("No " + strings.ToLower(resource.GetResourceNameFromType(m.activeTab)) + " match label selector " + selector + ".")
+			}
 		}
 
 		// Status message
@@ -138,206 +152,50 @@ func (m Model) View() string {
 		if m.statusMessage != "" {
 			statusView = "\n" + successStyle.Render(m.statusMessage)
 		}
+		if rateLimitLabel := m.getRateLimitLabel(); rateLimitLabel != "" {
+			statusView += "\n" + rateLimitLabel
+		}
 
-		helpText := "Tab: switch view • ←/→: navigate tabs • Enter: actions • r: reload resources • q: back to projects"
+		helpText := "Tab: switch view • ←/→: navigate tabs • Enter: actions • i: view details • space/A: mark • x: bulk actions • L: label selector • /: search • w: IP lookup • f: filter • r: reload resources • R: auto-refresh • q: back to projects"
 		if m.activeTab == resource.ResourceServers {
-			helpText = "Tab: switch view • ←/→: navigate tabs • Enter: server actions • i: view details • r: reload resources • q: back to projects"
+			helpText = "Tab: switch view • ←/→: navigate tabs • Enter: server actions • i: view details • space/A: mark • x: bulk actions • L: label selector • /: search • w: IP lookup • f: filter • r: reload resources • R: auto-refresh • q: back to projects"
+		}
+		helpText += " • o: sort • v: preview • T: table view • M: topology • $: costs • a: audit log"
+		if len(m.forwardStack) > 0 {
+			helpText += " • ]: forward to " + getPageTitle(m.forwardStack[len(m.forwardStack)-1])
+		}
+		if m.isTableMode(m.activeTab) {
+			helpText += "\n</>: select column • s: sort by column • +/-: resize column • H: hide column • C: show all columns"
+		}
+		if markedCount := len(m.markedResources[m.activeTab]); markedCount > 0 {
+			projectHeader = fmt.Sprintf("%s • %d marked", projectHeader, markedCount)
+		}
+		if sortLabel := m.getSortLabel(m.activeTab); sortLabel != "" {
+			projectHeader = fmt.Sprintf("%s • ↕ %s", projectHeader, sortLabel)
+		}
+		if refreshLabel := m.getAutoRefreshLabel(); refreshLabel != "" {
+			projectHeader = fmt.Sprintf("%s • %s", projectHeader, refreshLabel)
+		}
+		if staleLabel := m.getStaleLabel(); staleLabel != "" {
+			projectHeader = fmt.Sprintf("%s • %s", projectHeader, warningStyle.Render(staleLabel))
 		}
 
 		return fmt.Sprintf(
-			"%s\n%s\n\n%s%s\n\n%s",
+			"%s\n%s\n\n%s%s%s\n\n%s",
 			infoStyle.Render(projectHeader),
 			tabsView,
+			m.renderProjectLoadErrors()+m.renderRemovedResources(),
 			listView,
 			statusView,
 			helpStyle.Render(helpText),
 		)
-	case stateLoadBalancerServiceView:
-		// Render the Load Balancer services, as a list of loadbalancerServices
-		var serviceView strings.Builder
-		serviceView.WriteString(fmt.Sprintf("%s\n\n", titleStyle.Render("Load Balancer Services")))
-		resourceInfo := fmt.Sprintf("🔌Services for Load Balancer: %s", m.loadbalancerBeingViewed.Name)
-		serviceView.WriteString(infoStyle.Render(resourceInfo) + "\n\n")
-		if len(m.loadbalancerServices) == 0 {
-			noServicesMsg := "⚠️  No services found for this Load Balancer"
-			serviceView.WriteString(noServicesStyle.Render(noServicesMsg) + "\n")
-		} else {
-			// Create a container for all loadbalancerServices
-			var servicesContent strings.Builder
-			servicesContent.WriteString(fmt.Sprintf("Found %d service(s):\n\n", len(m.loadbalancerServices)))
-			// Render each service with improved styling
-			for i, service := range m.loadbalancerServices {
-				// Create a styled service description
-				serviceDesc := fmt.Sprintf("🔌Service %d: %s (Protocol: %s, Port: %d -> %d)", i+1, service.Protocol, service.Protocol, service.ListenPort, service.DestinationPort)
-				serviceStyled := serviceStyle.Render(serviceDesc)
-				// Add the service to the content
-				servicesContent.WriteString(serviceStyled + "\n")
-				// Add spacing between services (except for the last one)
-				if i < len(m.loadbalancerServices)-1 {
-					servicesContent.WriteString("\n")
-				}
-			}
-			// Wrap all services in a container
-			serviceView.WriteString(serviceContainerStyle.Render(servicesContent.String()) + "\n")
-		}
-		// Enhanced help text
-		helpText := "💡 Press 'q' to return to Load Balancer view"
-		serviceView.WriteString("\n" + helpStyle.Render(helpText))
-		return serviceView.String()
-
-	case stateLoadBalancerTargetView:
-		// Render the Load Balancer targets, as a list of loadbalancerTargets
-		var targetView strings.Builder
-		targetView.WriteString(fmt.Sprintf("%s\n\n", titleStyle.Render("Load Balancer Targets")))
-		resourceInfo := fmt.Sprintf("🎯Targets for Load Balancer: %s", m.loadbalancerBeingViewed.Name)
-		targetView.WriteString(infoStyle.Render(resourceInfo) + "\n\n")
-		if len(m.loadbalancerTargets) == 0 {
-			noTargetsMsg := "⚠️  No targets found for this Load Balancer"
-			targetView.WriteString(noTargetsStyle.Render(noTargetsMsg) + "\n")
-		} else {
-			// Create a container for all loadbalancerTargets
-			var targetsContent strings.Builder
-			targetsContent.WriteString(fmt.Sprintf("Found %d target(s):\n\n", len(m.loadbalancerTargets)))
-			// Render each target with improved styling
-			for i, target := range m.loadbalancerTargets {
-				// Create a styled target description
-				targetDesc := fmt.Sprintf("🎯Target %d: %s (Type: %s, Target count: %d)", i+1, target.LabelSelector.Selector, target.Type, len(target.Targets))
-				targetStyled := targetStyle.Render(targetDesc)
-				// Add the target to the content
-				targetsContent.WriteString(targetStyled + "\n")
-				// Add spacing between targets (except for the last one)
-				if i < len(m.loadbalancerTargets)-1 {
-					targetsContent.WriteString("\n")
-				}
-			}
-			// Wrap all targets in a container
-			targetView.WriteString(targetContainerStyle.Render(targetsContent.String()) + "\n")
-		}
-		// Enhanced help text
-		helpText := "💡 Press 'q' to return to Load Balancer view"
-		targetView.WriteString("\n" + helpStyle.Render(helpText))
-		return targetView.String()
-	case stateFirewallRuleView:
-		var ruleView strings.Builder
-		ruleView.WriteString(fmt.Sprintf("%s\n\n", titleStyle.Render("Firewall Rules")))
-		resourceInfo := fmt.Sprintf("🧱 Rules for Firewall: %s", m.firewallBeingViewed.Name)
-		ruleView.WriteString(infoStyle.Render(resourceInfo) + "\n\n")
-		if len(m.firewallRules) == 0 {
-			noRulesMsg := "⚠️  No rules found for this Firewall"
-			ruleView.WriteString(noFirewallRulesStyle.Render(noRulesMsg) + "\n")
-		} else {
-			var rulesContent strings.Builder
-			rulesContent.WriteString(fmt.Sprintf("Found %d rule(s):\n\n", len(m.firewallRules)))
-			for i, rule := range m.firewallRules {
-				ruleDesc := fmt.Sprintf("🧱 Rule %d: %s %s %s", i+1, strings.ToUpper(string(rule.Direction)), strings.ToUpper(string(rule.Protocol)), formatFirewallPort(rule.Port))
-				ruleDetails := fmt.Sprintf("Sources: %s | Destinations: %s", formatIPNets(rule.SourceIPs), formatIPNets(rule.DestinationIPs))
-				if rule.Description != nil && *rule.Description != "" {
-					ruleDetails = fmt.Sprintf("%s | %s", ruleDetails, *rule.Description)
-				}
-				ruleStyled := firewallRuleStyle.Render(ruleDesc + "\n" + ruleDetails)
-				rulesContent.WriteString(ruleStyled + "\n")
-				if i < len(m.firewallRules)-1 {
-					rulesContent.WriteString("\n")
-				}
-			}
-			ruleView.WriteString(firewallRuleContainerStyle.Render(rulesContent.String()) + "\n")
-		}
-		helpText := "💡 Press 'q' to return to Firewall view"
-		ruleView.WriteString("\n" + helpStyle.Render(helpText))
-		return ruleView.String()
-
-	case stateNetworkSubnetView:
-		var subnetView strings.Builder
-		subnetView.WriteString(fmt.Sprintf("%s\n\n", titleStyle.Render("Network Subnets")))
-		resourceInfo := fmt.Sprintf("🧩 Subnets for Network: %s", m.networkBeingViewed.Name)
-		subnetView.WriteString(infoStyle.Render(resourceInfo) + "\n\n")
-		if len(m.networkSubnets) == 0 {
-			noSubnetsMsg := "⚠️  No subnets found for this Network"
-			subnetView.WriteString(noSubnetsStyle.Render(noSubnetsMsg) + "\n")
-		} else {
-			var subnetsContent strings.Builder
-			subnetsContent.WriteString(fmt.Sprintf("Found %d subnet(s):\n\n", len(m.networkSubnets)))
-			for i, subnet := range m.networkSubnets {
-				subnetDesc := fmt.Sprintf("🧩 Subnet %d: %s (%s)", i+1, subnet.IPRange.String(), strings.ToUpper(string(subnet.Type)))
-				subnetDetails := fmt.Sprintf("Network Zone: %s | Gateway: %s", subnet.NetworkZone, formatSubnetGateway(subnet.Gateway))
-				subnetStyled := subnetStyle.Render(subnetDesc + "\n" + subnetDetails)
-				subnetsContent.WriteString(subnetStyled + "\n")
-				if i < len(m.networkSubnets)-1 {
-					subnetsContent.WriteString("\n")
-				}
-			}
-			subnetView.WriteString(subnetContainerStyle.Render(subnetsContent.String()) + "\n")
-		}
-		helpText := "💡 Press 'q' to return to Network view"
-		subnetView.WriteString("\n" + helpStyle.Render(helpText))
-		return subnetView.String()
-
-	case stateServerDetailView:
-		if m.serverBeingViewed == nil {
-			return fmt.Sprintf(
-				"\n%s\n\n%s\n\n%s\n",
-				titleStyle.Render("Server Details"),
-				warningStyle.Render("No server details available."),
-				helpStyle.Render("Press 'q' to return to resource view"),
-			)
-		}
-
-		server := m.serverBeingViewed
-		var detailView strings.Builder
-		detailView.WriteString(fmt.Sprintf("%s\n\n", titleStyle.Render("Server Details")))
-		header := fmt.Sprintf("🖥️ Server: %s (ID: %d)", server.Name, server.ID)
-		detailView.WriteString(infoStyle.Render(header) + "\n\n")
-
-		overviewLines := []string{
-			fmt.Sprintf("Status: %s", server.Status),
-			fmt.Sprintf("Type: %s", formatServerType(server)),
-			fmt.Sprintf("Datacenter: %s", formatDatacenter(server)),
-			fmt.Sprintf("Image: %s", formatServerImage(server)),
-			fmt.Sprintf("Created: %s", server.Created.Format("2006-01-02 15:04:05")),
-			fmt.Sprintf("Rescue Enabled: %t", server.RescueEnabled),
-		}
-		if server.PlacementGroup != nil {
-			overviewLines = append(overviewLines, fmt.Sprintf("Placement Group: %s", server.PlacementGroup.Name))
-		}
-		columns, columnWidth, gap := serverDetailGridLayout(m.width)
-		overviewSection := renderServerDetailSection("Overview", overviewLines, columnWidth)
-
-		networkLines := []string{
-			fmt.Sprintf("Public IPv4: %s", formatIP(server.PublicNet.IPv4.IP)),
-			fmt.Sprintf("Public IPv6: %s", formatIP(server.PublicNet.IPv6.IP)),
-			fmt.Sprintf("Floating IPs: %s", formatFloatingIPs(server.PublicNet.FloatingIPs)),
-		}
-		privateNetLines := formatPrivateNetworks(server)
-		if len(privateNetLines) > 0 {
-			networkLines = append(networkLines, "Private Networks:")
-			networkLines = append(networkLines, privateNetLines...)
-		}
-		networkSection := renderServerDetailSection("Networking", networkLines, columnWidth)
-		subnetSection := renderServerDetailSection("Subnets", formatServerSubnets(m.serverDetailNetworks), columnWidth)
-		firewallSection := renderServerDetailSection("Firewalls", formatServerFirewalls(server), columnWidth)
-		loadBalancerSection := renderServerDetailSection("Load Balancers", formatServerLoadBalancers(server), columnWidth)
-		volumeSection := renderServerDetailSection("Volumes", formatServerVolumes(server), columnWidth)
-		labelSection := renderServerDetailSection("Labels", formatServerLabels(server), columnWidth)
-
-		sections := []string{
-			overviewSection,
-			networkSection,
-			subnetSection,
-			firewallSection,
-			loadBalancerSection,
-			volumeSection,
-			labelSection,
-		}
-
-		detailView.WriteString(renderServerDetailGrid(sections, columns, gap) + "\n")
-
-		helpText := "💡 Press 'q' to return to resource view"
-		detailView.WriteString(helpStyle.Render(helpText))
-		return detailView.String()
+	case stateDetailView:
+		return m.renderDetail()
 
 	case stateLabelView:
 		// Render the label View
 		var labelView strings.Builder
+		labelView.WriteString(m.renderBreadcrumbs() + "\n\n")
 		labelView.WriteString(fmt.Sprintf("%s\n\n", titleStyle.Render("Labels")))
 
 		// Resource info with better styling
@@ -388,65 +246,49 @@ func (m Model) View() string {
 		}
 
 		// Enhanced help text
-		helpText := "💡 Press 'q' to return to resource view"
+		helpText := "💡 q/[: back • ]: forward"
 		labelView.WriteString("\n" + helpStyle.Render(helpText))
 
 		return labelView.String()
 	case stateContextMenu:
-		// Render the current resource view in background
-		projectHeader := fmt.Sprintf("Project: %s", m.currentProject)
-		if m.currentProject == "" {
-			projectHeader = "One-time Access"
-		}
-
-		var tabs []string
-		for i, tab := range resourceTabs {
-			if resource.ResourceType(i) == m.activeTab {
-				tabs = append(tabs, titleStyle.Render(tab))
-			} else {
-				tabs = append(tabs, helpStyle.Render(tab))
-			}
-		}
-		tabsView := strings.Join(tabs, " ")
-
-		var listView string
-		if currentList, exists := m.Lists[m.activeTab]; exists {
-			listView = currentList.View()
-		}
-
-		// Render context menu with number shortcuts
-		var menuItems []string
-		for i, item := range m.contextMenu.Items {
-			// Get the number for this item (1-indexed, with 0 for 10th)
-			numberStr := util.GetNumberForIndex(i)
-
-			// Create the menu item with number prefix
-			menuText := fmt.Sprintf("[%s] %s", numberStr, item.Label)
-
-			if i == m.contextMenu.SelectedItem {
-				menuItems = append(menuItems, selectedMenuStyle.Render(menuText))
-			} else {
-				menuItems = append(menuItems, menuText)
-			}
+		return m.renderMenuOverlay(fmt.Sprintf("Actions for %s:", resource.GetResourceNameFromType(m.contextMenu.ResourceType)), m.contextMenu)
+	case stateBulkMenu:
+		return m.renderMenuOverlay(fmt.Sprintf("Bulk actions for %d marked %s:", len(m.bulkTargets), strings.ToLower(resource.GetResourceNameFromType(m.bulkMenu.ResourceType))), m.bulkMenu)
+	case stateBulkInput:
+		statusView := ""
+		if m.statusMessage != "" {
+			statusView = "\n\n" + warningStyle.Render(m.statusMessage)
 		}
-
-		menuContent := strings.Join(menuItems, "\n")
-
-		// Add help text about number shortcuts
-		helpText := "\nPress number keys for quick selection • ↑/↓ to navigate • Enter to select • Esc to cancel"
-		menu := menuStyle.Render(fmt.Sprintf("Actions for %s:\n\n%s%s",
-
-			resource.GetResourceNameFromType(m.contextMenu.ResourceType),
-
-			menuContent,
-			helpStyle.Render(helpText)))
-
-		// Center the menu
-		menuOverlay := lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, menu)
-
-		background := fmt.Sprintf("%s\n%s\n\n%s", infoStyle.Render(projectHeader), tabsView, listView)
-
-		return lipgloss.Place(m.width, m.height, lipgloss.Left, lipgloss.Top, background) + menuOverlay
+		return fmt.Sprintf(
+			"\n%s\n\n%s\n\n%s%s\n\n%s\n",
+			titleStyle.Render("lazyhetzner - Bulk "+bulk.GetActionLabel(m.bulkAction)),
+			infoStyle.Render(bulk.GetInputPrompt(m.bulkMenu.ResourceType, m.bulkAction, len(m.bulkTargets))),
+			m.bulkInput.View(),
+			statusView,
+			helpStyle.Render("Enter: run • Esc: cancel"),
+		)
+	case stateConfirmInput:
+		return m.renderConfirmInput()
+	case stateBulkResultView:
+		return m.renderBulkResults()
+	case stateSearch:
+		return m.renderSearch()
+	case stateIPLookup:
+		return m.renderIPLookup()
+	case stateTopologyView:
+		return m.renderTopology()
+	case stateCostView:
+		return m.renderCostView()
+	case stateAuditView:
+		return m.renderAuditLog()
+	case stateLabelSelectorInput:
+		return fmt.Sprintf(
+			"\n%s\n\n%s\n\n%s\n\n%s\n",
+			titleStyle.Render("lazyhetzner - Label Selector for "+resource.GetResourceNameFromType(m.activeTab)),
+			infoStyle.Render("Filter by Hetzner label selector, e.g. env=prod,role in (web,api),!deprecated. Leave empty to clear."),
+			m.labelSelectorInput.View(),
+			helpStyle.Render("Enter: apply • Esc: cancel"),
+		)
 	case stateError:
 		return fmt.Sprintf(
 			"\n%s\n\n%s\n\n%s\n",
@@ -459,32 +301,116 @@ func (m Model) View() string {
 	return ""
 }
 
-func formatFirewallPort(port *string) string {
-	if port == nil || *port == "" {
-		return "all ports"
+func (m Model) getProjectHeader() string {
+	if m.aggregated {
+		return fmt.Sprintf("All projects (%d)", len(m.projectClients))
+	}
+	if m.currentProject == "" {
+		return "One-time Access"
+	}
+	if m.isReadOnly(m.currentProject) {
+		return fmt.Sprintf("Project: %s • 🔒 read-only", m.currentProject)
 	}
-	return fmt.Sprintf("port %s", *port)
+	return fmt.Sprintf("Project: %s", m.currentProject)
 }
 
-func formatIPNets(nets []net.IPNet) string {
-	if len(nets) == 0 {
-		return "any"
+// renderMenuOverlay renders a numbered action menu centered on top of the current resource list
+func (m Model) renderMenuOverlay(title string, menu ctm.ContextMenu) string {
+	// Render the current resource view in background
+	projectHeader := m.getProjectHeader()
+
+	var tabs []string
+	for _, rt := range getTabs() {
+		tab := m.getTabTitle(rt)
+		if rt == m.activeTab {
+			tabs = append(tabs, titleStyle.Render(tab))
+		} else {
+			tabs = append(tabs, helpStyle.Render(tab))
+		}
 	}
-	parts := make([]string, 0, len(nets))
-	for _, ipNet := range nets {
-		parts = append(parts, ipNet.String())
+	tabsView := strings.Join(tabs, " ")
+
+	var listView string
+	if currentList, exists := m.Lists[m.activeTab]; exists {
+		listView = m.renderResourceList(m.activeTab, currentList)
 	}
-	return strings.Join(parts, ", ")
+
+	// Render menu with number shortcuts
+	var menuItems []string
+	for i, item := range menu.Items {
+		// Get the number for this item (1-indexed, with 0 for 10th)
+		numberStr := util.GetNumberForIndex(i)
+
+		// Create the menu item with number prefix
+		menuText := fmt.Sprintf("[%s] %s", numberStr, item.Label)
+
+		if i == menu.SelectedItem {
+			menuItems = append(menuItems, selectedMenuStyle.Render(menuText))
+		} else {
+			menuItems = append(menuItems, menuText)
+		}
+	}
+
+	menuContent := strings.Join(menuItems, "\n")
+
+	// Add help text about number shortcuts
+	helpText := "\nPress number keys for quick selection • ↑/↓ to navigate • Enter to select • Esc to cancel"
+	menuView := menuStyle.Render(fmt.Sprintf("%s\n\n%s%s", title, menuContent, helpStyle.Render(helpText)))
+
+	// Center the menu
+	menuOverlay := lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, menuView)
+
+	background := fmt.Sprintf("%s\n%s\n\n%s", infoStyle.Render(projectHeader), tabsView, listView)
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Left, lipgloss.Top, background) + menuOverlay
 }
 
-func formatSubnetGateway(gateway net.IP) string {
-	if gateway == nil || len(gateway) == 0 {
-		return "n/a"
+func (m Model) renderBulkResults() string {
+	var resultView strings.Builder
+	resultView.WriteString(fmt.Sprintf("%s\n\n", titleStyle.Render("Bulk Action Results")))
+	if m.bulkResults == nil {
+		resultView.WriteString(warningStyle.Render("No bulk action has been run yet.") + "\n")
+		resultView.WriteString("\n" + helpStyle.Render("💡 Press 'q' to return to resource view"))
+		return resultView.String()
+	}
+
+	failed := len(m.bulkResults.Failed())
+	succeeded := len(m.bulkResults.Results) - failed
+	summary := fmt.Sprintf("%s on %d %s: %d succeeded, %d failed",
+		bulk.GetActionLabel(m.bulkResults.Action),
+		len(m.bulkResults.Results),
+		strings.ToLower(resource.GetResourceNameFromType(m.bulkResults.ResourceType)),
+		succeeded,
+		failed,
+	)
+	if failed > 0 {
+		resultView.WriteString(warningStyle.Render(summary) + "\n\n")
+	} else {
+		resultView.WriteString(infoStyle.Render(summary) + "\n\n")
+	}
+
+	var resultsContent strings.Builder
+	for i, result := range m.bulkResults.Results {
+		line := fmt.Sprintf("✅ %s (ID: %d)", result.Target.DisplayName(), result.Target.ID)
+		if result.Err != nil {
+			line = errorStyle.Render(fmt.Sprintf("❌ %s (ID: %d): %v", result.Target.DisplayName(), result.Target.ID, result.Err))
+		}
+		resultsContent.WriteString(line)
+		if i < len(m.bulkResults.Results)-1 {
+			resultsContent.WriteString("\n")
+		}
+	}
+	resultView.WriteString(labelContainerStyle.Render(resultsContent.String()) + "\n")
+
+	helpText := "💡 Press 'q' to return to resource view"
+	if failed > 0 {
+		helpText = "💡 Press 'r' to retry failed items • 'q' to return to resource view"
 	}
-	return gateway.String()
+	resultView.WriteString("\n" + helpStyle.Render(helpText))
+	return resultView.String()
 }
 
-func renderServerDetailSection(title string, lines []string, width int) string {
+func renderDetailSection(title string, lines []string, width int) string {
 	if len(lines) == 0 {
 		lines = []string{"No data available."}
 	}
@@ -494,12 +420,13 @@ func renderServerDetailSection(title string, lines []string, width int) string {
 	innerWidth := max(10, width-4)
 
 	innerStyle := lipgloss.NewStyle().Width(innerWidth).MaxWidth(innerWidth)
-	titleLine := innerStyle.Render(serverDetailTitleStyle.Render(wrapText(title, innerWidth)))
+	titleLine := innerStyle.Render(detailTitleStyle.Render(wrapText(title, innerWidth)))
 	contentBlock := innerStyle.Render(wrapText(content, innerWidth))
 
-	return serverDetailSectionStyle.Render(lipgloss.JoinVertical(lipgloss.Left, titleLine, contentBlock))
+	return detailSectionStyle.Render(lipgloss.JoinVertical(lipgloss.Left, titleLine, contentBlock))
 }
-func serverDetailGridLayout(width int) (int, int, int) {
+
+func detailGridLayout(width int) (int, int, int) {
 	gridWidth := max(30, width-4)
 	minColumnWidth := 36
 	maxColumns := 2
@@ -523,7 +450,7 @@ func serverDetailGridLayout(width int) (int, int, int) {
 	return columns, columnWidth, gap
 }
 
-func renderServerDetailGrid(sections []string, columns int, gap int) string {
+func renderDetailGrid(sections []string, columns int, gap int) string {
 	if len(sections) == 0 {
 		return ""
 	}
@@ -553,13 +480,6 @@ func renderServerDetailGrid(sections []string, columns int, gap int) string {
 	return strings.Join(rows, "\n")
 }
 
-func formatIP(ip net.IP) string {
-	if ip == nil || len(ip) == 0 {
-		return "n/a"
-	}
-	return ip.String()
-}
-
 func wrapText(text string, width int) string {
 	if width <= 0 || text == "" {
 		return text
@@ -600,152 +520,95 @@ func wrapLine(line string, width int) []string {
 	return lines
 }
 
-func formatServerType(server *hcloud.Server) string {
-	if server.ServerType == nil {
-		return "n/a"
+// renderDetail renders the detail view of a resource, with its sections laid out as a grid
+func (m Model) renderDetail() string {
+	if m.detail == nil {
+		return fmt.Sprintf(
+			"\n%s\n\n%s\n\n%s\n",
+			titleStyle.Render("Details"),
+			warningStyle.Render("No details available."),
+			helpStyle.Render("Press 'q' to return to resource view"),
+		)
 	}
-	return server.ServerType.Name
-}
 
-func formatDatacenter(server *hcloud.Server) string {
-	if server.Datacenter == nil {
-		return "n/a"
-	}
-	return server.Datacenter.Name
-}
+	var detailView strings.Builder
+	detailView.WriteString(m.renderBreadcrumbs() + "\n\n")
+	detailView.WriteString(fmt.Sprintf("%s\n\n", titleStyle.Render(m.detail.Title)))
+	detailView.WriteString(infoStyle.Render(m.detail.Header) + "\n\n")
 
-func formatServerImage(server *hcloud.Server) string {
-	if server.Image == nil {
-		return "n/a"
-	}
-	if server.Image.Name != "" {
-		return server.Image.Name
-	}
-	return fmt.Sprintf("Image %d", server.Image.ID)
-}
+	body := m.renderDetailBody()
+	visible := m.getDetailBodyHeight()
+	start := min(m.detailScroll, max(0, len(body)-visible))
+	end := min(len(body), start+visible)
+	detailView.WriteString(strings.Join(body[start:end], "\n") + "\n")
 
-func formatFloatingIPs(floatingIPs []*hcloud.FloatingIP) string {
-	if len(floatingIPs) == 0 {
-		return "none"
+	helpText := "💡 q/[: back • ]: forward"
+	if len(getDetailLinks(m.detail)) > 0 {
+		helpText += " • ↑/↓: select link • Enter: open link"
+	} else {
+		helpText += " • ↑/↓: scroll"
 	}
-	parts := make([]string, 0, len(floatingIPs))
-	for _, floatingIP := range floatingIPs {
-		if floatingIP == nil {
-			continue
-		}
-		parts = append(parts, fmt.Sprintf("%s (ID: %d)", floatingIP.IP, floatingIP.ID))
+	if m.hasMetrics() {
+		helpText += " • t: metrics time range"
 	}
-	if len(parts) == 0 {
-		return "none"
+	if len(body) > visible {
+		helpText += fmt.Sprintf(" • pgup/pgdn: scroll (lines %d-%d of %d)", start+1, end, len(body))
 	}
-	return strings.Join(parts, ", ")
-}
-
-func formatPrivateNetworks(server *hcloud.Server) []string {
-	if server == nil || len(server.PrivateNet) == 0 {
-		return []string{"No private networks attached."}
-	}
-	lines := make([]string, 0, len(server.PrivateNet))
-	for _, privateNet := range server.PrivateNet {
-		networkName := "unknown"
-		networkID := int64(0)
-		if privateNet.Network != nil {
-			networkName = privateNet.Network.Name
-			networkID = privateNet.Network.ID
-		}
-		aliasText := "none"
-		if len(privateNet.Aliases) > 0 {
-			aliases := make([]string, 0, len(privateNet.Aliases))
-			for _, alias := range privateNet.Aliases {
-				if alias != nil {
-					aliases = append(aliases, alias.String())
-				}
-			}
-			if len(aliases) > 0 {
-				aliasText = strings.Join(aliases, ", ")
-			}
-		}
-		lines = append(lines, fmt.Sprintf("• %s (ID: %d) | IP: %s | MAC: %s | Aliases: %s", networkName, networkID, formatIP(privateNet.IP), privateNet.MACAddress, aliasText))
+	if m.statusMessage != "" {
+		detailView.WriteString(successStyle.Render(m.statusMessage) + "\n")
 	}
-	return lines
+	detailView.WriteString(helpStyle.Render(helpText))
+	return detailView.String()
 }
 
-func formatServerSubnets(networks []*hcloud.Network) []string {
-	if len(networks) == 0 {
-		return []string{"No subnets available."}
-	}
-	lines := []string{}
-	for _, network := range networks {
-		if network == nil {
-			continue
-		}
-		lines = append(lines, fmt.Sprintf("%s (ID: %d)", network.Name, network.ID))
-		if len(network.Subnets) == 0 {
-			lines = append(lines, "  • No subnets defined.")
-			continue
-		}
-		for _, subnet := range network.Subnets {
-			ipRange := "n/a"
-			if subnet.IPRange != nil {
-				ipRange = subnet.IPRange.String()
-			}
-			lines = append(lines, fmt.Sprintf("  • %s (%s) | Zone: %s | Gateway: %s", ipRange, strings.ToUpper(string(subnet.Type)), subnet.NetworkZone, formatSubnetGateway(subnet.Gateway)))
-		}
-	}
-	return lines
-}
+// Lines taken by the breadcrumbs, title, header and help of the detail view
+const detailChromeHeight = 8
 
-func formatServerFirewalls(server *hcloud.Server) []string {
-	if server == nil || len(server.PublicNet.Firewalls) == 0 {
-		return []string{"No firewalls attached."}
-	}
-	lines := make([]string, 0, len(server.PublicNet.Firewalls))
-	for _, firewallStatus := range server.PublicNet.Firewalls {
-		lines = append(lines, fmt.Sprintf("• %s (ID: %d) | Status: %s", firewallStatus.Firewall.Name, firewallStatus.Firewall.ID, firewallStatus.Status))
-	}
-	return lines
+func (m Model) getDetailBodyHeight() int {
+	return max(5, m.height-detailChromeHeight)
 }
 
-func formatServerLoadBalancers(server *hcloud.Server) []string {
-	if server == nil || len(server.LoadBalancers) == 0 {
-		return []string{"No load balancers attached."}
-	}
-	lines := make([]string, 0, len(server.LoadBalancers))
-	for _, lb := range server.LoadBalancers {
-		if lb == nil {
-			continue
-		}
-		lines = append(lines, fmt.Sprintf("• %s (ID: %d)", lb.Name, lb.ID))
-	}
-	return lines
-}
+// Marks the lines of detail views that link to another view
+const (
+	linkMarker         = "→ "
+	selectedLinkMarker = "▶ "
+)
 
-func formatServerVolumes(server *hcloud.Server) []string {
-	if server == nil || len(server.Volumes) == 0 {
-		return []string{"No volumes attached."}
-	}
-	lines := make([]string, 0, len(server.Volumes))
-	for _, volume := range server.Volumes {
-		if volume == nil {
-			continue
+// renderDetailBody renders the sections of the detail view as a grid, split into lines for scrolling.
+// Lines with a link are marked, and the selected link stands out.
+func (m Model) renderDetailBody() []string {
+	if m.detail == nil {
+		return nil
+	}
+	if len(m.detail.Sections) == 0 {
+		return strings.Split(noDetailsStyle.Render(m.detail.Empty), "\n")
+	}
+	selected := detailLink{Section: -1}
+	if links := getDetailLinks(m.detail); m.detailCursor < len(links) {
+		selected = links[m.detailCursor]
+	}
+	columns, columnWidth, gap := detailGridLayout(m.width)
+	sections := make([]string, 0, len(m.detail.Sections))
+	for sectionIndex, section := range m.detail.Sections {
+		lines := section.Lines
+		if len(section.Links) > 0 {
+			lines = make([]string, len(section.Lines))
+			for i, line := range section.Lines {
+				switch _, linked := section.Links[i]; {
+				case sectionIndex == selected.Section && i == selected.Line:
+					lines[i] = selectedLinkMarker + line
+				case linked:
+					lines[i] = linkMarker + line
+				default:
+					lines[i] = line
+				}
+			}
 		}
-		lines = append(lines, fmt.Sprintf("• %s (ID: %d) | Size: %d GB", volume.Name, volume.ID, volume.Size))
+		sections = append(sections, renderDetailSection(section.Title, lines, columnWidth))
 	}
-	return lines
-}
-
-func formatServerLabels(server *hcloud.Server) []string {
-	if server == nil || len(server.Labels) == 0 {
-		return []string{"No labels attached."}
-	}
-	keys := make([]string, 0, len(server.Labels))
-	for key := range server.Labels {
-		keys = append(keys, key)
+	body := renderDetailGrid(sections, columns, gap)
+	if m.hasMetrics() {
+		body = lipgloss.JoinVertical(lipgloss.Left, body, m.renderMetrics(columns*columnWidth+(columns-1)*gap))
 	}
-	sort.Strings(keys)
-	lines := make([]string, 0, len(keys))
-	for _, key := range keys {
-		lines = append(lines, fmt.Sprintf("• %s=%s", key, server.Labels[key]))
-	}
-	return lines
+	return strings.Split(body, "\n")
 }
@@ -0,0 +1,117 @@
+package ratelimit
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// MaxRetries is the number of times the hcloud client retries a request, like one rejected for exceeding the rate limit
+	MaxRetries = 4
+	// Backoff before the first retry if the API doesn't say when the limit resets
+	baseBackoff = time.Second
+	// Longest a single retry waits, even if the limit resets later
+	maxBackoff = 30 * time.Second
+)
+
+// Status is the rate limit budget reported by the most recent API response
+type Status struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+	// Known is false until the first response with rate limit headers was received
+	Known bool
+	// Throttled is set from a 429 response until the next accepted one
+	Throttled bool
+}
+
+// Low reports whether less than a quarter of the budget is left
+func (s Status) Low() bool {
+	return s.Known && s.Limit > 0 && s.Remaining*4 < s.Limit
+}
+
+// SlowdownFactor returns how much background work should be stretched to preserve the remaining budget
+func (s Status) SlowdownFactor() int {
+	switch {
+	case !s.Known || s.Limit <= 0:
+		return 1
+	case s.Throttled || s.Remaining*10 < s.Limit:
+		return 4
+	case s.Low():
+		return 2
+	default:
+		return 1
+	}
+}
+
+// Tracker records the rate limit headers of every response. It is safe for concurrent use.
+type Tracker struct {
+	mu     sync.RWMutex
+	status Status
+}
+
+func NewTracker() *Tracker {
+	return &Tracker{}
+}
+
+func (t *Tracker) Status() Status {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.status
+}
+
+func (t *Tracker) update(header http.Header, throttled bool) {
+	limit, limitErr := strconv.Atoi(header.Get("RateLimit-Limit"))
+	remaining, remainingErr := strconv.Atoi(header.Get("RateLimit-Remaining"))
+	reset, resetErr := strconv.ParseInt(header.Get("RateLimit-Reset"), 10, 64)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.status.Throttled = throttled
+	if limitErr != nil || remainingErr != nil {
+		return
+	}
+	t.status.Known = true
+	t.status.Limit = limit
+	t.status.Remaining = remaining
+	if resetErr == nil {
+		t.status.Reset = time.Unix(reset, 0)
+	}
+}
+
+// Backoff returns how long hcloud's retry handler waits before a retry: until the rate limit resets if the
+// request was rejected for exceeding it, or exponentially longer otherwise
+func (t *Tracker) Backoff(retries int) time.Duration {
+	backoff := baseBackoff << retries
+	if status := t.Status(); status.Throttled {
+		if untilReset := time.Until(status.Reset); untilReset > 0 {
+			backoff = untilReset
+		}
+	}
+	return min(backoff, maxBackoff)
+}
+
+// Transport records the rate limit headers of every response. Retrying is left to the hcloud client,
+// which waits as long as the tracker's Backoff says.
+type Transport struct {
+	Base    http.RoundTripper
+	Tracker *Tracker
+}
+
+func NewTransport(base http.RoundTripper, tracker *Tracker) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &Transport{Base: base, Tracker: tracker}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.Base.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	t.Tracker.update(resp.Header, resp.StatusCode == http.StatusTooManyRequests)
+	return resp, nil
+}
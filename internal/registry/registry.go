@@ -0,0 +1,197 @@
+// Package registry holds the resource types shown as tabs. Each resource package registers its
+// Kind on import, so the model drives tabs, context menus and detail views without per-type code.
+package registry
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	ctm "github.com/grammeaway/lazyhetzner/internal/context_menu"
+	"github.com/grammeaway/lazyhetzner/internal/metrics"
+	"github.com/grammeaway/lazyhetzner/internal/resource"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+// Kind is implemented by every resource type shown as a tab
+type Kind interface {
+	Type() resource.ResourceType
+	// Name is the plural display name, used as tab title
+	Name() string
+	// Key is a stable identifier, used as key in the config file
+	Key() string
+
+	// Load lists the resources, optionally filtered by a label selector
+	Load(ctx context.Context, client *hcloud.Client, labelSelector string, serverIndex *resource.ServerIndexStore) tea.Cmd
+	// Items converts the message of a completed load into list items, if the message belongs to this kind
+	Items(msg tea.Msg) ([]list.Item, bool)
+	// ItemID returns the resource ID of one of this kind's list items
+	ItemID(item list.Item) (int64, bool)
+	Labels(item list.Item) map[string]string
+	// Fingerprint summarizes the fields besides the labels that a refresh reports as changed
+	Fingerprint(item list.Item) string
+	// Columns are the columns of the tab in table mode, in their default order
+	Columns() []Column
+	// SortKeys are the fields the tab can be sorted by, in the order they are cycled through
+	SortKeys() []SortKey
+
+	ContextMenu(item list.Item) (ctm.ContextMenu, bool)
+	ExecuteAction(ctx context.Context, action string, item list.Item, env ActionEnv) tea.Cmd
+	// Detail converts a message asking for a detail view of this kind into its content
+	Detail(msg tea.Msg) (Detail, bool)
+	// Preview returns the details of a list item that are known without further requests
+	Preview(item list.Item) (Detail, bool)
+}
+
+// ActionEnv holds what context actions may need besides the resource itself
+type ActionEnv struct {
+	// Client of the project the resource belongs to
+	Client          *hcloud.Client
+	DefaultTerminal string
+}
+
+// Detail is the content of a detail view. The model renders the sections as a grid.
+type Detail struct {
+	Title string
+	// Name is a short name of what the view shows, used in breadcrumbs
+	Name     string
+	Header   string
+	Sections []DetailSection
+	// Empty is shown instead of the sections when there are none
+	Empty string
+	// Resource is the resource the view shows, if it is about a single one. Views of kinds with
+	// metrics chart them below the sections.
+	Resource Link
+}
+
+type DetailSection struct {
+	Title string
+	Lines []string
+	// Links makes lines selectable, keyed by their index in Lines
+	Links map[int]Link
+}
+
+// Link points from a line of a detail view to the view of another resource
+type Link struct {
+	ResourceType resource.ResourceType
+	ID           int64
+	// Part names a part of the resource with a view of its own, like a subnet of a network.
+	// Links without one open the resource itself.
+	Part string
+}
+
+// PartKind is implemented by kinds whose resources have parts with a view of their own
+type PartKind interface {
+	// PartDetail returns the view of a part of one of the kind's list items
+	PartDetail(item list.Item, part string, related Related) (Detail, bool)
+}
+
+// MetricsKind is implemented by kinds whose resources have metrics to chart in their detail view
+type MetricsKind interface {
+	// LoadMetrics fetches the metrics of one of the kind's list items over the window, replying with a metrics.LoadedMsg
+	LoadMetrics(ctx context.Context, client *hcloud.Client, item list.Item, window metrics.Window) tea.Cmd
+}
+
+// BulkKind is implemented by kinds whose resources can be marked for bulk actions. Every such kind
+// supports adding and removing labels and deleting, besides its own bulk actions.
+type BulkKind interface {
+	// ItemName returns the name of one of the kind's list items, as listed in bulk results
+	ItemName(item list.Item) string
+	// GetLabels fetches the current labels of a resource
+	GetLabels(ctx context.Context, client *hcloud.Client, id int64) (map[string]string, error)
+	// UpdateLabels replaces the labels of a resource
+	UpdateLabels(ctx context.Context, client *hcloud.Client, id int64, labels map[string]string) error
+	// Delete deletes a resource, waiting for the deletion to finish if it runs as an action
+	Delete(ctx context.Context, client *hcloud.Client, id int64) error
+	// BulkActions are the kind's own bulk actions, offered between the label actions and deleting
+	BulkActions() []BulkAction
+}
+
+// BulkAction is a bulk action only some kinds offer, like powering servers on
+type BulkAction struct {
+	Item ctm.ContextMenuItem
+	// Name is a short human readable name, used in bulk results and the audit log
+	Name string
+	// Prompt asks for the parameter of the action. Actions without one run without asking, unless
+	// their menu item is destructive: those ask to type how many resources they affect.
+	Prompt string
+	// ParamName names the parameter in the audit log
+	ParamName string
+	// Resolve converts the parameter once per project before the action runs on the resources,
+	// like a firewall name into its ID. It is optional.
+	Resolve func(ctx context.Context, client *hcloud.Client, param string) (string, error)
+	// Run runs the action on a single resource, waiting for it to finish
+	Run func(ctx context.Context, client *hcloud.Client, id int64, param string) error
+}
+
+// Related returns the loaded list items of a resource type, for views that show related resources
+type Related func(rt resource.ResourceType) []list.Item
+
+// Column is a column of a tab shown as a table
+type Column struct {
+	// Key is a stable identifier, used as key in the config file
+	Key   string
+	Title string
+	// Width is the default width in characters
+	Width int
+	// Value renders the cell of one of the kind's list items
+	Value func(item list.Item) string
+	// Less orders items by the column. Columns without it are ordered by their value.
+	Less func(a, b list.Item) bool
+}
+
+// SortKey is a field a tab can be sorted by
+type SortKey struct {
+	// Key is a stable identifier, used as key in the config file. A sort key shares it with the column it sorts by.
+	Key   string
+	Title string
+	Less  func(a, b list.Item) bool
+}
+
+var kinds = map[resource.ResourceType]Kind{}
+
+// Register adds a resource type. It is meant to be called from the init function of the resource package.
+func Register(kind Kind) {
+	if _, exists := kinds[kind.Type()]; exists {
+		panic(fmt.Sprintf("registry: resource type %d registered twice", kind.Type()))
+	}
+	kinds[kind.Type()] = kind
+	resource.RegisterType(kind.Type(), kind.Name(), kind.Key())
+}
+
+func Get(rt resource.ResourceType) (Kind, bool) {
+	kind, exists := kinds[rt]
+	return kind, exists
+}
+
+// GetBulkKind returns the kind of a resource type if its resources can be marked for bulk actions
+func GetBulkKind(rt resource.ResourceType) (Kind, BulkKind, bool) {
+	kind, exists := kinds[rt]
+	if !exists {
+		return nil, nil, false
+	}
+	bulkKind, ok := kind.(BulkKind)
+	return kind, bulkKind, ok
+}
+
+// Kinds returns the registered resource types in tab order
+func Kinds() []Kind {
+	sorted := make([]Kind, 0, len(kinds))
+	for _, kind := range kinds {
+		sorted = append(sorted, kind)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Type() < sorted[j].Type() })
+	return sorted
+}
+
+// GetItemResourceID returns the resource ID of a list item of any registered kind
+func GetItemResourceID(item list.Item) (int64, bool) {
+	for _, kind := range kinds {
+		if id, ok := kind.ItemID(item); ok {
+			return id, true
+		}
+	}
+	return 0, false
+}
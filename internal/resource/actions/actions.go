@@ -0,0 +1,199 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/grammeaway/lazyhetzner/internal/message"
+	"github.com/grammeaway/lazyhetzner/internal/resource"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud/schema"
+)
+
+// How many actions are fetched per resource collection, and how many of them the Actions tab keeps
+const (
+	recentPerCollection = 50
+	recentActions       = 100
+)
+
+// How many actions the history of a single resource shows
+const historyLength = 50
+
+// apiResources maps the resource types with a tab to their name in actions and their path in the API
+var apiResources = []struct {
+	resourceType resource.ResourceType
+	actionType   hcloud.ActionResourceType
+	path         string
+}{
+	{resource.ResourceServers, hcloud.ActionResourceTypeServer, "servers"},
+	{resource.ResourceNetworks, "network", "networks"},
+	{resource.ResourceLoadBalancers, "load_balancer", "load_balancers"},
+	{resource.ResourceFloatingIPs, hcloud.ActionResourceTypeFloatingIP, "floating_ips"},
+	{resource.ResourceFirewalls, "firewall", "firewalls"},
+	{resource.ResourceVolumes, hcloud.ActionResourceTypeVolume, "volumes"},
+}
+
+// getResourceType returns the tab of a resource an action refers to, if it has one
+func getResourceType(actionType hcloud.ActionResourceType) (resource.ResourceType, bool) {
+	for _, apiResource := range apiResources {
+		if apiResource.actionType == actionType {
+			return apiResource.resourceType, true
+		}
+	}
+	return 0, false
+}
+
+func getResourcePath(rt resource.ResourceType) (string, bool) {
+	for _, apiResource := range apiResources {
+		if apiResource.resourceType == rt {
+			return apiResource.path, true
+		}
+	}
+	return "", false
+}
+
+type ActionsLoadedMsg struct {
+	Actions []*hcloud.Action
+}
+
+// ResourceActionsLoadedMsg holds the most recent actions of a single resource
+type ResourceActionsLoadedMsg struct {
+	ResourceType resource.ResourceType
+	ResourceID   int64
+	ResourceName string
+	Actions      []*hcloud.Action
+}
+
+type ActionItem struct {
+	Action       *hcloud.Action
+	ResourceType resource.ResourceType
+	ResourceID   int64
+}
+
+func (i ActionItem) FilterValue() string {
+	return strings.Join(append([]string{i.Action.Command, string(i.Action.Status)}, formatResources(i.Action)...), " ")
+}
+func (i ActionItem) Title() string { return i.Action.Command }
+func (i ActionItem) Description() string {
+	return fmt.Sprintf("%s | %s | Started %s | %s", formatStatus(i.Action), formatProgress(i.Action), resource.FormatTime(i.Action.Started), strings.Join(formatResources(i.Action), ", "))
+}
+
+// LoadActions lists the most recent actions of the project. The API only lists actions per resource
+// collection, so the collections are listed concurrently and merged, most recent first.
+// Actions have no labels, so the label selector does not apply to them.
+func LoadActions(ctx context.Context, client *hcloud.Client) tea.Cmd {
+	return func() tea.Msg {
+		collections := []*hcloud.ResourceActionClient{
+			client.Server.Action,
+			client.Network.Action,
+			client.LoadBalancer.Action,
+			client.FloatingIP.Action,
+			client.Firewall.Action,
+			client.Volume.Action,
+			client.PrimaryIP.Action,
+			client.Image.Action,
+			client.Certificate.Action,
+		}
+		results := make([][]*hcloud.Action, len(collections))
+		errs := make([]error, len(collections))
+		var wg sync.WaitGroup
+		for i, collection := range collections {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				results[i], _, errs[i] = collection.List(ctx, hcloud.ActionListOpts{
+					ListOpts: hcloud.ListOpts{PerPage: recentPerCollection},
+					Sort:     []string{"started:desc"},
+				})
+			}()
+		}
+		wg.Wait()
+
+		// Actions on several resources, like attaching a volume, are listed by each of their collections
+		seen := make(map[int64]bool)
+		var actions []*hcloud.Action
+		for i, result := range results {
+			if errs[i] != nil {
+				return message.ErrorMsg{Err: errs[i]}
+			}
+			for _, action := range result {
+				if !seen[action.ID] {
+					seen[action.ID] = true
+					actions = append(actions, action)
+				}
+			}
+		}
+		slices.SortStableFunc(actions, func(a, b *hcloud.Action) int { return b.Started.Compare(a.Started) })
+		return ActionsLoadedMsg{Actions: actions[:min(len(actions), recentActions)]}
+	}
+}
+
+// LoadResourceActions lists the most recent actions of a single resource. hcloud-go has no call for
+// the actions of one resource, so the endpoint is requested directly.
+func LoadResourceActions(ctx context.Context, client *hcloud.Client, rt resource.ResourceType, id int64, name string) tea.Cmd {
+	return func() tea.Msg {
+		path, ok := getResourcePath(rt)
+		if !ok {
+			return message.ErrorMsg{Err: fmt.Errorf("%s have no action history", strings.ToLower(resource.GetResourceNameFromType(rt)))}
+		}
+		req, err := client.NewRequest(ctx, http.MethodGet, fmt.Sprintf("/%s/%d/actions?sort=started:desc&per_page=%d", path, id, historyLength), nil)
+		if err != nil {
+			return message.ErrorMsg{Err: err}
+		}
+		var body schema.ActionListResponse
+		if _, err := client.Do(req, &body); err != nil {
+			return message.ErrorMsg{Err: err}
+		}
+		loaded := ResourceActionsLoadedMsg{ResourceType: rt, ResourceID: id, ResourceName: name}
+		for _, action := range body.Actions {
+			loaded.Actions = append(loaded.Actions, hcloud.ActionFromSchema(action))
+		}
+		return loaded
+	}
+}
+
+func formatStatus(action *hcloud.Action) string {
+	switch action.Status {
+	case hcloud.ActionStatusSuccess:
+		return "✅ success"
+	case hcloud.ActionStatusError:
+		return "❌ error"
+	case hcloud.ActionStatusRunning:
+		return "⏳ running"
+	default:
+		return "❓ " + string(action.Status)
+	}
+}
+
+func formatProgress(action *hcloud.Action) string {
+	return strconv.Itoa(action.Progress) + "%"
+}
+
+func formatFinished(action *hcloud.Action) string {
+	if action.Finished.IsZero() {
+		return "not yet"
+	}
+	return fmt.Sprintf("%s (took %s)", resource.FormatTime(action.Finished), action.Finished.Sub(action.Started).Round(1e9))
+}
+
+func formatError(action *hcloud.Action) string {
+	if action.ErrorCode == "" && action.ErrorMessage == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s: %s", action.ErrorCode, action.ErrorMessage)
+}
+
+// formatResources returns the resources of an action as "type ID" strings
+func formatResources(action *hcloud.Action) []string {
+	resources := make([]string, 0, len(action.Resources))
+	for _, actionResource := range action.Resources {
+		resources = append(resources, fmt.Sprintf("%s %d", actionResource.Type, actionResource.ID))
+	}
+	return resources
+}
@@ -0,0 +1,71 @@
+package actions
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/grammeaway/lazyhetzner/internal/registry"
+	"github.com/grammeaway/lazyhetzner/internal/resource"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+func (kind) Columns() []registry.Column {
+	return []registry.Column{
+		{
+			Key: "started", Title: "Started", Width: 19,
+			Value: actionValue(func(a *hcloud.Action) string { return resource.FormatTime(a.Started) }),
+			Less:  actionLess(func(a, b *hcloud.Action) bool { return a.Started.Before(b.Started) }),
+		},
+		{Key: "command", Title: "Command", Width: 26, Value: actionValue(func(a *hcloud.Action) string { return a.Command })},
+		{Key: "status", Title: "Status", Width: 10, Value: actionValue(func(a *hcloud.Action) string { return string(a.Status) })},
+		{
+			Key: "progress", Title: "Progress", Width: 8,
+			Value: actionValue(formatProgress),
+			Less:  actionLess(func(a, b *hcloud.Action) bool { return a.Progress < b.Progress }),
+		},
+		{
+			Key: "finished", Title: "Finished", Width: 19,
+			Value: actionValue(func(a *hcloud.Action) string { return resource.FormatTime(a.Finished) }),
+			Less:  actionLess(func(a, b *hcloud.Action) bool { return a.Finished.Before(b.Finished) }),
+		},
+		{Key: "resources", Title: "Resources", Width: 28, Value: actionValue(func(a *hcloud.Action) string { return strings.Join(formatResources(a), ", ") })},
+		{Key: "error", Title: "Error", Width: 30, Value: actionValue(formatError)},
+	}
+}
+
+func actionValue(value func(*hcloud.Action) string) func(list.Item) string {
+	return func(item list.Item) string {
+		if actionItem, ok := item.(ActionItem); ok {
+			return value(actionItem.Action)
+		}
+		return ""
+	}
+}
+
+func actionLess(less func(a, b *hcloud.Action) bool) func(a, b list.Item) bool {
+	return func(a, b list.Item) bool {
+		actionA, okA := a.(ActionItem)
+		actionB, okB := b.(ActionItem)
+		return okA && okB && less(actionA.Action, actionB.Action)
+	}
+}
+
+func (kind) SortKeys() []registry.SortKey {
+	return []registry.SortKey{
+		{Key: "started", Title: "started", Less: actionLess(func(a, b *hcloud.Action) bool { return a.Started.Before(b.Started) })},
+		{Key: "command", Title: "command", Less: actionLess(func(a, b *hcloud.Action) bool { return a.Command < b.Command })},
+		// Failed actions come first, as those are the ones worth looking into
+		{Key: "status", Title: "status", Less: actionLess(func(a, b *hcloud.Action) bool { return getStatusRank(a.Status) < getStatusRank(b.Status) })},
+	}
+}
+
+func getStatusRank(status hcloud.ActionStatus) int {
+	switch status {
+	case hcloud.ActionStatusError:
+		return 0
+	case hcloud.ActionStatusRunning:
+		return 1
+	default:
+		return 2
+	}
+}
@@ -0,0 +1,61 @@
+package actions
+
+import (
+	"fmt"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+	ctm "github.com/grammeaway/lazyhetzner/internal/context_menu"
+	"github.com/grammeaway/lazyhetzner/internal/message"
+	"github.com/grammeaway/lazyhetzner/internal/resource"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+// HistoryMenuItem is the context menu item of every resource type that shows its action history
+var HistoryMenuItem = ctm.ContextMenuItem{Label: "📜 View Action History", Action: "view_actions"}
+
+func CreateActionContextMenu(action *hcloud.Action) ctm.ContextMenu {
+	return ctm.ContextMenu{
+		Items:        getActionMenuItems(),
+		SelectedItem: 0,
+		ResourceType: resource.ResourceActions,
+		ResourceID:   action.ID,
+	}
+}
+
+func getActionMenuItems() []ctm.ContextMenuItem {
+	return []ctm.ContextMenuItem{
+		{Label: "❌ Cancel", Action: "cancel"},
+		{Label: "📋 Copy Action ID", Action: "copy_id"},
+		{Label: "📋 Copy Error Message", Action: "copy_error"},
+	}
+}
+
+func ExecuteActionContextAction(selectedAction string, action *hcloud.Action) tea.Cmd {
+	switch selectedAction {
+	case "cancel":
+		return func() tea.Msg {
+			return message.CancelCtxMenuMsg{}
+		}
+	case "copy_id":
+		return func() tea.Msg {
+			if err := clipboard.WriteAll(fmt.Sprintf("%d", action.ID)); err != nil {
+				return message.ErrorMsg{Err: err}
+			}
+			return message.ClipboardCopiedMsg(fmt.Sprintf("Action ID %d copied to clipboard", action.ID))
+		}
+	case "copy_error":
+		return func() tea.Msg {
+			actionError := formatError(action)
+			if actionError == "" {
+				return message.StatusMsg("This action has no error.")
+			}
+			if err := clipboard.WriteAll(actionError); err != nil {
+				return message.ErrorMsg{Err: err}
+			}
+			return message.ClipboardCopiedMsg("Error of action " + action.Command + " copied to clipboard")
+		}
+	default:
+		return nil
+	}
+}
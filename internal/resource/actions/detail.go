@@ -0,0 +1,107 @@
+package actions
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/grammeaway/lazyhetzner/internal/registry"
+	"github.com/grammeaway/lazyhetzner/internal/resource"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+func (kind) Preview(item list.Item) (registry.Detail, bool) {
+	actionItem, ok := item.(ActionItem)
+	if !ok {
+		return registry.Detail{}, false
+	}
+	action := actionItem.Action
+	overviewLines := []string{
+		fmt.Sprintf("Command: %s", action.Command),
+		fmt.Sprintf("Status: %s", formatStatus(action)),
+		fmt.Sprintf("Progress: %s", formatProgress(action)),
+		fmt.Sprintf("Started: %s", resource.FormatTime(action.Started)),
+		fmt.Sprintf("Finished: %s", formatFinished(action)),
+	}
+	errorLines := []string{"No error."}
+	if action.Status == hcloud.ActionStatusError || formatError(action) != "" {
+		errorLines = []string{
+			fmt.Sprintf("Code: %s", action.ErrorCode),
+			fmt.Sprintf("Message: %s", action.ErrorMessage),
+		}
+	}
+	resourceLines, resourceLinks := formatResourceLinks(action)
+
+	return registry.Detail{
+		Title:  "Action Details",
+		Name:   action.Command,
+		Header: fmt.Sprintf("📜 Action: %s (ID: %d)", action.Command, action.ID),
+		Sections: []registry.DetailSection{
+			{Title: "Overview", Lines: overviewLines},
+			{Title: "Error", Lines: errorLines},
+			{Title: "Resources", Lines: resourceLines, Links: resourceLinks},
+		},
+	}, true
+}
+
+// formatResourceLinks lists the resources of an action, linking those shown in a tab
+func formatResourceLinks(action *hcloud.Action) ([]string, map[int]registry.Link) {
+	if len(action.Resources) == 0 {
+		return []string{"No resources."}, nil
+	}
+	lines := make([]string, 0, len(action.Resources))
+	links := make(map[int]registry.Link)
+	for _, actionResource := range action.Resources {
+		if rt, ok := getResourceType(actionResource.Type); ok {
+			links[len(lines)] = registry.Link{ResourceType: rt, ID: actionResource.ID}
+		}
+		lines = append(lines, fmt.Sprintf("• %s %d", actionResource.Type, actionResource.ID))
+	}
+	return lines, links
+}
+
+// getHistoryDetail shows the actions of a single resource, most recent first, with a summary of how they went
+func getHistoryDetail(loaded ResourceActionsLoadedMsg) registry.Detail {
+	resourceName := resource.GetResourceNameFromType(loaded.ResourceType)
+	detail := registry.Detail{
+		Title:  "Action History",
+		Name:   "Action History",
+		Header: fmt.Sprintf("📜 Action history of %s (%s, ID: %d), up to the last %d", loaded.ResourceName, resourceName, loaded.ResourceID, historyLength),
+		Empty:  "No actions recorded for this resource.",
+	}
+	if len(loaded.Actions) == 0 {
+		return detail
+	}
+
+	counts := make(map[hcloud.ActionStatus]int)
+	var lastFailure *hcloud.Action
+	for _, action := range loaded.Actions {
+		counts[action.Status]++
+		if action.Status == hcloud.ActionStatusError && lastFailure == nil {
+			lastFailure = action
+		}
+	}
+	summaryLines := []string{
+		fmt.Sprintf("Actions: %d", len(loaded.Actions)),
+		fmt.Sprintf("Succeeded: %d", counts[hcloud.ActionStatusSuccess]),
+		fmt.Sprintf("Failed: %d", counts[hcloud.ActionStatusError]),
+		fmt.Sprintf("Running: %d", counts[hcloud.ActionStatusRunning]),
+	}
+	if lastFailure != nil {
+		summaryLines = append(summaryLines, fmt.Sprintf("Last failure: %s at %s", lastFailure.Command, resource.FormatTime(lastFailure.Started)))
+	}
+
+	actionLines := make([]string, 0, len(loaded.Actions))
+	for _, action := range loaded.Actions {
+		actionLines = append(actionLines, fmt.Sprintf("• %s %s | %s | %s | finished %s",
+			resource.FormatTime(action.Started), action.Command, formatStatus(action), formatProgress(action), formatFinished(action)))
+		if actionError := formatError(action); actionError != "" {
+			actionLines = append(actionLines, "    ⚠️  "+actionError)
+		}
+	}
+
+	detail.Sections = []registry.DetailSection{
+		{Title: "Summary", Lines: summaryLines},
+		{Title: "Actions", Lines: actionLines},
+	}
+	return detail
+}
@@ -0,0 +1,86 @@
+package actions
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	ctm "github.com/grammeaway/lazyhetzner/internal/context_menu"
+	"github.com/grammeaway/lazyhetzner/internal/registry"
+	"github.com/grammeaway/lazyhetzner/internal/resource"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+type kind struct{}
+
+func init() {
+	registry.Register(kind{})
+}
+
+func (kind) Type() resource.ResourceType { return resource.ResourceActions }
+func (kind) Name() string                { return "Actions" }
+func (kind) Key() string                 { return "actions" }
+
+func (kind) Load(ctx context.Context, client *hcloud.Client, _ string, _ *resource.ServerIndexStore) tea.Cmd {
+	return LoadActions(ctx, client)
+}
+
+func (kind) Items(msg tea.Msg) ([]list.Item, bool) {
+	loaded, ok := msg.(ActionsLoadedMsg)
+	if !ok {
+		return nil, false
+	}
+	items := make([]list.Item, len(loaded.Actions))
+	for i, action := range loaded.Actions {
+		items[i] = ActionItem{
+			Action:       action,
+			ResourceType: resource.ResourceActions,
+			ResourceID:   action.ID,
+		}
+	}
+	return items, true
+}
+
+func (kind) ItemID(item list.Item) (int64, bool) {
+	if actionItem, ok := item.(ActionItem); ok {
+		return actionItem.Action.ID, true
+	}
+	return 0, false
+}
+
+// Actions have no labels
+func (kind) Labels(list.Item) map[string]string {
+	return nil
+}
+
+func (kind) Fingerprint(item list.Item) string {
+	actionItem, ok := item.(ActionItem)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("%s|%d", actionItem.Action.Status, actionItem.Action.Progress)
+}
+
+func (kind) ContextMenu(item list.Item) (ctm.ContextMenu, bool) {
+	if actionItem, ok := item.(ActionItem); ok {
+		return CreateActionContextMenu(actionItem.Action), true
+	}
+	return ctm.ContextMenu{}, false
+}
+
+func (kind) ExecuteAction(_ context.Context, action string, item list.Item, _ registry.ActionEnv) tea.Cmd {
+	if actionItem, ok := item.(ActionItem); ok {
+		return ExecuteActionContextAction(action, actionItem.Action)
+	}
+	return nil
+}
+
+// Detail shows the action history of a single resource, which any kind's context menu may ask for
+func (kind) Detail(msg tea.Msg) (registry.Detail, bool) {
+	loaded, ok := msg.(ResourceActionsLoadedMsg)
+	if !ok {
+		return registry.Detail{}, false
+	}
+	return getHistoryDetail(loaded), true
+}
@@ -0,0 +1,40 @@
+package firewall
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/grammeaway/lazyhetzner/internal/registry"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+func (kind) ItemName(item list.Item) string {
+	if i, ok := item.(FirewallItem); ok {
+		return i.Firewall.Name
+	}
+	return ""
+}
+
+func (kind) GetLabels(ctx context.Context, client *hcloud.Client, id int64) (map[string]string, error) {
+	firewall, _, err := client.Firewall.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if firewall == nil {
+		return nil, fmt.Errorf("firewall %d not found", id)
+	}
+	return firewall.Labels, nil
+}
+
+func (kind) UpdateLabels(ctx context.Context, client *hcloud.Client, id int64, labels map[string]string) error {
+	_, _, err := client.Firewall.Update(ctx, &hcloud.Firewall{ID: id}, hcloud.FirewallUpdateOpts{Labels: labels})
+	return err
+}
+
+func (kind) Delete(ctx context.Context, client *hcloud.Client, id int64) error {
+	_, err := client.Firewall.Delete(ctx, &hcloud.Firewall{ID: id})
+	return err
+}
+
+func (kind) BulkActions() []registry.BulkAction { return nil }
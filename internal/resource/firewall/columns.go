@@ -0,0 +1,51 @@
+package firewall
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/grammeaway/lazyhetzner/internal/registry"
+	"github.com/grammeaway/lazyhetzner/internal/resource"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+func (kind) Columns() []registry.Column {
+	return []registry.Column{
+		{Key: "name", Title: "Name", Width: 24, Value: firewallValue(func(f *hcloud.Firewall) string { return f.Name })},
+		{
+			Key: "rules", Title: "Rules", Width: 5,
+			Value: firewallValue(func(f *hcloud.Firewall) string { return strconv.Itoa(len(f.Rules)) }),
+			Less:  firewallLess(func(a, b *hcloud.Firewall) bool { return len(a.Rules) < len(b.Rules) }),
+		},
+		{
+			Key: "applied_to", Title: "Applied To", Width: 10,
+			Value: firewallValue(func(f *hcloud.Firewall) string { return strconv.Itoa(len(f.AppliedTo)) }),
+			Less:  firewallLess(func(a, b *hcloud.Firewall) bool { return len(a.AppliedTo) < len(b.AppliedTo) }),
+		},
+		{Key: "labels", Title: "Labels", Width: 30, Value: firewallValue(func(f *hcloud.Firewall) string { return resource.FormatLabels(f.Labels) })},
+	}
+}
+
+func firewallValue(value func(*hcloud.Firewall) string) func(list.Item) string {
+	return func(item list.Item) string {
+		if firewallItem, ok := item.(FirewallItem); ok {
+			return value(firewallItem.Firewall)
+		}
+		return ""
+	}
+}
+
+func firewallLess(less func(a, b *hcloud.Firewall) bool) func(a, b list.Item) bool {
+	return func(a, b list.Item) bool {
+		firewallA, okA := a.(FirewallItem)
+		firewallB, okB := b.(FirewallItem)
+		return okA && okB && less(firewallA.Firewall, firewallB.Firewall)
+	}
+}
+
+func (kind) SortKeys() []registry.SortKey {
+	return []registry.SortKey{
+		{Key: "name", Title: "name", Less: firewallLess(func(a, b *hcloud.Firewall) bool { return strings.ToLower(a.Name) < strings.ToLower(b.Name) })},
+	}
+}
@@ -8,7 +8,7 @@ import (
 	ctm "github.com/grammeaway/lazyhetzner/internal/context_menu"
 	"github.com/grammeaway/lazyhetzner/internal/message"
 	"github.com/grammeaway/lazyhetzner/internal/resource"
-	r_firewall "github.com/grammeaway/lazyhetzner/internal/resource/firewall"
+	"github.com/grammeaway/lazyhetzner/internal/resource/actions"
 	"github.com/grammeaway/lazyhetzner/internal/resource/label"
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
 )
@@ -35,6 +35,7 @@ func getFirewallMenuItems() []ctm.ContextMenuItem {
 		{Label: "❌ Cancel", Action: "cancel"},
 		{Label: "🔒 View Rules", Action: "view_rules"},
 		{Label: "🔖 View Labels", Action: "view_labels"},
+		actions.HistoryMenuItem,
 		{Label: "📋 Copy Firewall ID", Action: "copy_id"},
 		{Label: "📋 Copy Firewall Name", Action: "copy_name"},
 	}
@@ -51,7 +52,7 @@ func ExecuteFirewallContextAction(selectedAction string, firewall *hcloud.Firewa
 			if len(firewall.Rules) == 0 {
 				return message.StatusMsg("No rules found for this firewall.")
 			}
-			return r_firewall.ViewFirewallRulesMsg{
+			return ViewFirewallRulesMsg{
 				Firewall: firewall,
 				Rules:    firewall.Rules,
 			}
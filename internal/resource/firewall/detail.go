@@ -0,0 +1,74 @@
+package firewall
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/grammeaway/lazyhetzner/internal/registry"
+	"github.com/grammeaway/lazyhetzner/internal/resource"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+func (kind) Preview(item list.Item) (registry.Detail, bool) {
+	firewallItem, ok := item.(FirewallItem)
+	if !ok {
+		return registry.Detail{}, false
+	}
+	firewall := firewallItem.Firewall
+	appliedLines, appliedLinks := formatAppliedTo(firewall.AppliedTo)
+	return registry.Detail{
+		Title:  "Firewall Details",
+		Name:   firewall.Name,
+		Header: fmt.Sprintf("🧱 Firewall: %s (ID: %d)", firewall.Name, firewall.ID),
+		Sections: []registry.DetailSection{
+			{
+				Title: "Overview",
+				Lines: []string{
+					fmt.Sprintf("Created: %s", resource.FormatTime(firewall.Created)),
+					fmt.Sprintf("Rules: %d", len(firewall.Rules)),
+					fmt.Sprintf("Applied to: %d", len(firewall.AppliedTo)),
+				},
+			},
+			{Title: "Rules", Lines: formatRules(firewall.Rules)},
+			{Title: "Applied To", Lines: appliedLines, Links: appliedLinks},
+			{Title: "Labels", Lines: resource.GetLabelLines(firewall.Labels)},
+		},
+	}, true
+}
+
+func formatRules(rules []hcloud.FirewallRule) []string {
+	if len(rules) == 0 {
+		return []string{"No rules defined."}
+	}
+	lines := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		peers := fmt.Sprintf("from %s", formatIPNets(rule.SourceIPs))
+		if rule.Direction == hcloud.FirewallRuleDirectionOut {
+			peers = fmt.Sprintf("to %s", formatIPNets(rule.DestinationIPs))
+		}
+		lines = append(lines, fmt.Sprintf("• %s %s %s %s", strings.ToUpper(string(rule.Direction)), strings.ToUpper(string(rule.Protocol)), formatFirewallPort(rule.Port), peers))
+	}
+	return lines
+}
+
+// formatAppliedTo lists the resources the firewall is applied to, linking servers to their view
+func formatAppliedTo(resources []hcloud.FirewallResource) ([]string, map[int]registry.Link) {
+	if len(resources) == 0 {
+		return []string{"Not applied to any resources."}, nil
+	}
+	lines := make([]string, 0, len(resources))
+	links := map[int]registry.Link{}
+	for _, applied := range resources {
+		switch {
+		case applied.Server != nil:
+			links[len(lines)] = registry.Link{ResourceType: resource.ResourceServers, ID: applied.Server.ID}
+			lines = append(lines, fmt.Sprintf("• Server %d", applied.Server.ID))
+		case applied.LabelSelector != nil:
+			lines = append(lines, fmt.Sprintf("• Label Selector %s", applied.LabelSelector.Selector))
+		default:
+			lines = append(lines, fmt.Sprintf("• %s", applied.Type))
+		}
+	}
+	return lines, links
+}
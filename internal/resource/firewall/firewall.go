@@ -3,6 +3,7 @@ package firewall
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/grammeaway/lazyhetzner/internal/message"
@@ -32,10 +33,22 @@ func (i FirewallItem) Description() string {
 	return fmt.Sprintf("Rules: %d | Applied to: %d", len(i.Firewall.Rules), len(i.Firewall.AppliedTo))
 }
 
-func LoadFirewalls(client *hcloud.Client) tea.Cmd {
+// SearchTerms returns the strings the global search matches a firewall against
+func (i FirewallItem) SearchTerms() []string {
+	terms := []string{i.Firewall.Name, strconv.FormatInt(i.Firewall.ID, 10)}
+	for _, appliedTo := range i.Firewall.AppliedTo {
+		if appliedTo.LabelSelector != nil {
+			terms = append(terms, appliedTo.LabelSelector.Selector)
+		}
+	}
+	return append(terms, resource.GetLabelTerms(i.Firewall.Labels)...)
+}
+
+func LoadFirewalls(ctx context.Context, client *hcloud.Client, labelSelector string) tea.Cmd {
 	return func() tea.Msg {
-		ctx := context.Background()
-		firewalls, err := client.Firewall.All(ctx)
+		firewalls, err := client.Firewall.AllWithOpts(ctx, hcloud.FirewallListOpts{
+			ListOpts: hcloud.ListOpts{LabelSelector: labelSelector},
+		})
 		if err != nil {
 			return message.ErrorMsg{Err: err}
 		}
@@ -0,0 +1,131 @@
+package firewall
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	ctm "github.com/grammeaway/lazyhetzner/internal/context_menu"
+	"github.com/grammeaway/lazyhetzner/internal/registry"
+	"github.com/grammeaway/lazyhetzner/internal/resource"
+	"github.com/grammeaway/lazyhetzner/internal/resource/actions"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+type kind struct{}
+
+func init() {
+	registry.Register(kind{})
+}
+
+func (kind) Type() resource.ResourceType { return resource.ResourceFirewalls }
+func (kind) Name() string                { return "Firewalls" }
+func (kind) Key() string                 { return "firewalls" }
+
+func (kind) Load(ctx context.Context, client *hcloud.Client, labelSelector string, _ *resource.ServerIndexStore) tea.Cmd {
+	return LoadFirewalls(ctx, client, labelSelector)
+}
+
+func (kind) Items(msg tea.Msg) ([]list.Item, bool) {
+	loaded, ok := msg.(FirewallsLoadedMsg)
+	if !ok {
+		return nil, false
+	}
+	items := make([]list.Item, len(loaded.Firewalls))
+	for i, firewall := range loaded.Firewalls {
+		items[i] = FirewallItem{
+			Firewall:     firewall,
+			ResourceType: resource.ResourceFirewalls,
+			ResourceID:   firewall.ID,
+		}
+	}
+	return items, true
+}
+
+func (kind) ItemID(item list.Item) (int64, bool) {
+	if firewallItem, ok := item.(FirewallItem); ok {
+		return firewallItem.Firewall.ID, true
+	}
+	return 0, false
+}
+
+func (kind) Labels(item list.Item) map[string]string {
+	if firewallItem, ok := item.(FirewallItem); ok {
+		return getFirewallLabels(firewallItem.Firewall)
+	}
+	return nil
+}
+
+func (kind) Fingerprint(item list.Item) string {
+	firewallItem, ok := item.(FirewallItem)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("rules=%d|applied=%d", len(firewallItem.Firewall.Rules), len(firewallItem.Firewall.AppliedTo))
+}
+
+func (kind) ContextMenu(item list.Item) (ctm.ContextMenu, bool) {
+	if firewallItem, ok := item.(FirewallItem); ok {
+		return CreateFirewallContextMenu(firewallItem.Firewall), true
+	}
+	return ctm.ContextMenu{}, false
+}
+
+func (kind) ExecuteAction(ctx context.Context, action string, item list.Item, env registry.ActionEnv) tea.Cmd {
+	if firewallItem, ok := item.(FirewallItem); ok {
+		if action == actions.HistoryMenuItem.Action {
+			return actions.LoadResourceActions(ctx, env.Client, resource.ResourceFirewalls, firewallItem.Firewall.ID, firewallItem.Firewall.Name)
+		}
+		return ExecuteFirewallContextAction(action, firewallItem.Firewall)
+	}
+	return nil
+}
+
+func (kind) Detail(msg tea.Msg) (registry.Detail, bool) {
+	rulesMsg, ok := msg.(ViewFirewallRulesMsg)
+	if !ok {
+		return registry.Detail{}, false
+	}
+	sections := make([]registry.DetailSection, 0, len(rulesMsg.Rules))
+	for i, rule := range rulesMsg.Rules {
+		lines := []string{
+			fmt.Sprintf("Sources: %s", formatIPNets(rule.SourceIPs)),
+			fmt.Sprintf("Destinations: %s", formatIPNets(rule.DestinationIPs)),
+		}
+		if rule.Description != nil && *rule.Description != "" {
+			lines = append(lines, *rule.Description)
+		}
+		sections = append(sections, registry.DetailSection{
+			Title: fmt.Sprintf("🧱 Rule %d: %s %s %s", i+1, strings.ToUpper(string(rule.Direction)), strings.ToUpper(string(rule.Protocol)), formatFirewallPort(rule.Port)),
+			Lines: lines,
+		})
+	}
+	return registry.Detail{
+		Title:    "Firewall Rules",
+		Name:     rulesMsg.Firewall.Name + " rules",
+		Header:   fmt.Sprintf("🧱 Rules for Firewall: %s", rulesMsg.Firewall.Name),
+		Sections: sections,
+		Empty:    "⚠️  No rules found for this Firewall",
+	}, true
+}
+
+func formatFirewallPort(port *string) string {
+	if port == nil || *port == "" {
+		return "all ports"
+	}
+	return fmt.Sprintf("port %s", *port)
+}
+
+func formatIPNets(nets []net.IPNet) string {
+	if len(nets) == 0 {
+		return "any"
+	}
+	parts := make([]string, 0, len(nets))
+	for _, ipNet := range nets {
+		parts = append(parts, ipNet.String())
+	}
+	return strings.Join(parts, ", ")
+}
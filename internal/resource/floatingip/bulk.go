@@ -0,0 +1,40 @@
+package floatingip
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/grammeaway/lazyhetzner/internal/registry"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+func (kind) ItemName(item list.Item) string {
+	if i, ok := item.(FloatingIPItem); ok {
+		return i.Title()
+	}
+	return ""
+}
+
+func (kind) GetLabels(ctx context.Context, client *hcloud.Client, id int64) (map[string]string, error) {
+	floatingIP, _, err := client.FloatingIP.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if floatingIP == nil {
+		return nil, fmt.Errorf("floating IP %d not found", id)
+	}
+	return floatingIP.Labels, nil
+}
+
+func (kind) UpdateLabels(ctx context.Context, client *hcloud.Client, id int64, labels map[string]string) error {
+	_, _, err := client.FloatingIP.Update(ctx, &hcloud.FloatingIP{ID: id}, hcloud.FloatingIPUpdateOpts{Labels: labels})
+	return err
+}
+
+func (kind) Delete(ctx context.Context, client *hcloud.Client, id int64) error {
+	_, err := client.FloatingIP.Delete(ctx, &hcloud.FloatingIP{ID: id})
+	return err
+}
+
+func (kind) BulkActions() []registry.BulkAction { return nil }
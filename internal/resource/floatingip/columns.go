@@ -0,0 +1,77 @@
+package floatingip
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/grammeaway/lazyhetzner/internal/registry"
+	"github.com/grammeaway/lazyhetzner/internal/resource"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+func (kind) Columns() []registry.Column {
+	return []registry.Column{
+		{Key: "name", Title: "Name", Width: 24, Value: floatingIPValue(floatingIPDisplayName)},
+		{
+			Key: "ip", Title: "IP", Width: 18,
+			Value: floatingIPValue(func(f *hcloud.FloatingIP) string { return resource.FormatIP(f.IP) }),
+			Less:  floatingIPLess(func(a, b *hcloud.FloatingIP) bool { return resource.LessIP(a.IP, b.IP) }),
+		},
+		{Key: "type", Title: "Type", Width: 4, Value: floatingIPValue(func(f *hcloud.FloatingIP) string { return string(f.Type) })},
+		{Key: "assigned_to", Title: "Assigned To", Width: 20, Value: floatingIPValue(formatAssignment)},
+		{
+			Key: "location", Title: "Location", Width: 8,
+			Value: floatingIPValue(func(f *hcloud.FloatingIP) string {
+				if f.HomeLocation == nil {
+					return "n/a"
+				}
+				return f.HomeLocation.Name
+			}),
+		},
+		{Key: "labels", Title: "Labels", Width: 30, Value: floatingIPValue(func(f *hcloud.FloatingIP) string { return resource.FormatLabels(f.Labels) })},
+	}
+}
+
+func floatingIPValue(value func(*hcloud.FloatingIP) string) func(list.Item) string {
+	return func(item list.Item) string {
+		if floatingIPItem, ok := item.(FloatingIPItem); ok {
+			return value(floatingIPItem.FloatingIP)
+		}
+		return ""
+	}
+}
+
+func floatingIPLess(less func(a, b *hcloud.FloatingIP) bool) func(a, b list.Item) bool {
+	return func(a, b list.Item) bool {
+		floatingIPA, okA := a.(FloatingIPItem)
+		floatingIPB, okB := b.(FloatingIPItem)
+		return okA && okB && less(floatingIPA.FloatingIP, floatingIPB.FloatingIP)
+	}
+}
+
+func formatAssignment(floatingIP *hcloud.FloatingIP) string {
+	switch {
+	case floatingIP.Blocked:
+		return "blocked"
+	case floatingIP.Server == nil:
+		return "unassigned"
+	case floatingIP.Server.Name != "":
+		return floatingIP.Server.Name
+	default:
+		return fmt.Sprintf("Server %d", floatingIP.Server.ID)
+	}
+}
+
+func (kind) SortKeys() []registry.SortKey {
+	return []registry.SortKey{
+		{
+			Key: "name", Title: "name",
+			Less: floatingIPLess(func(a, b *hcloud.FloatingIP) bool {
+				return strings.ToLower(floatingIPDisplayName(a)) < strings.ToLower(floatingIPDisplayName(b))
+			}),
+		},
+		// Unassigned floating IPs come first, as those are the ones that cost money without being used
+		{Key: "assigned", Title: "assigned", Less: floatingIPLess(func(a, b *hcloud.FloatingIP) bool { return a.Server == nil && b.Server != nil })},
+	}
+}
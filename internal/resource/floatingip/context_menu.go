@@ -8,6 +8,7 @@ import (
 	ctm "github.com/grammeaway/lazyhetzner/internal/context_menu"
 	"github.com/grammeaway/lazyhetzner/internal/message"
 	"github.com/grammeaway/lazyhetzner/internal/resource"
+	"github.com/grammeaway/lazyhetzner/internal/resource/actions"
 	"github.com/grammeaway/lazyhetzner/internal/resource/label"
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
 )
@@ -33,6 +34,7 @@ func getFloatingIPMenuItems() []ctm.ContextMenuItem {
 	return []ctm.ContextMenuItem{
 		{Label: "❌ Cancel", Action: "cancel"},
 		{Label: "🔖 View Labels", Action: "view_labels"},
+		actions.HistoryMenuItem,
 		{Label: "📋 Copy Floating IP ID", Action: "copy_id"},
 		{Label: "📋 Copy Floating IP Name", Action: "copy_name"},
 		{Label: "📋 Copy Floating IP Address", Action: "copy_ip"},
@@ -90,16 +92,3 @@ func ExecuteFloatingIPContextAction(selectedAction string, floatingIP *hcloud.Fl
 		return nil
 	}
 }
-
-func floatingIPDisplayName(floatingIP *hcloud.FloatingIP) string {
-	if floatingIP == nil {
-		return "Unknown Floating IP"
-	}
-	if floatingIP.Name != "" {
-		return floatingIP.Name
-	}
-	if floatingIP.IP != nil {
-		return floatingIP.IP.String()
-	}
-	return fmt.Sprintf("Floating IP %d", floatingIP.ID)
-}
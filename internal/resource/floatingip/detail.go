@@ -0,0 +1,58 @@
+package floatingip
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/grammeaway/lazyhetzner/internal/registry"
+	"github.com/grammeaway/lazyhetzner/internal/resource"
+)
+
+func (kind) Preview(item list.Item) (registry.Detail, bool) {
+	floatingIPItem, ok := item.(FloatingIPItem)
+	if !ok {
+		return registry.Detail{}, false
+	}
+	floatingIP := floatingIPItem.FloatingIP
+	location := "n/a"
+	if floatingIP.HomeLocation != nil {
+		location = floatingIP.HomeLocation.Name
+	}
+	overviewLines := []string{
+		fmt.Sprintf("IP: %s", resource.FormatIP(floatingIP.IP)),
+		fmt.Sprintf("Type: %s", floatingIPProtocolDisplay(floatingIP.Type)),
+		fmt.Sprintf("Home Location: %s", location),
+		fmt.Sprintf("Created: %s", resource.FormatTime(floatingIP.Created)),
+		fmt.Sprintf("Blocked: %t", floatingIP.Blocked),
+		fmt.Sprintf("Delete Protection: %t", floatingIP.Protection.Delete),
+	}
+	if floatingIP.Description != "" {
+		overviewLines = append(overviewLines, fmt.Sprintf("Description: %s", floatingIP.Description))
+	}
+
+	dnsLines := []string{"No reverse DNS entries."}
+	if len(floatingIP.DNSPtr) > 0 {
+		ips := make([]string, 0, len(floatingIP.DNSPtr))
+		for ip := range floatingIP.DNSPtr {
+			ips = append(ips, ip)
+		}
+		sort.Strings(ips)
+		dnsLines = make([]string, 0, len(ips))
+		for _, ip := range ips {
+			dnsLines = append(dnsLines, fmt.Sprintf("• %s -> %s", ip, floatingIP.DNSPtr[ip]))
+		}
+	}
+
+	return registry.Detail{
+		Title:  "Floating IP Details",
+		Name:   floatingIPDisplayName(floatingIP),
+		Header: fmt.Sprintf("📍 Floating IP: %s (ID: %d)", floatingIPDisplayName(floatingIP), floatingIP.ID),
+		Sections: []registry.DetailSection{
+			{Title: "Overview", Lines: overviewLines},
+			{Title: "Assignment", Lines: []string{fmt.Sprintf("Assigned to: %s", formatAssignment(floatingIP))}},
+			{Title: "Reverse DNS", Lines: dnsLines},
+			{Title: "Labels", Lines: resource.GetLabelLines(floatingIP.Labels)},
+		},
+	}, true
+}
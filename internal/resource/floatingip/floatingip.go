@@ -3,6 +3,7 @@ package floatingip
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -38,19 +39,36 @@ func (i FloatingIPItem) Description() string {
 	return fmt.Sprintf("%s | %s | %s", status, protocol, ipAddress)
 }
 
-func LoadFloatingIPs(client *hcloud.Client) tea.Cmd {
+// SearchTerms returns the strings the global search matches a floating IP against
+func (i FloatingIPItem) SearchTerms() []string {
+	terms := []string{floatingIPDisplayName(i.FloatingIP), strconv.FormatInt(i.FloatingIP.ID, 10)}
+	if i.FloatingIP.IP != nil {
+		terms = append(terms, i.FloatingIP.IP.String())
+	}
+	if i.FloatingIP.Network != nil {
+		terms = append(terms, i.FloatingIP.Network.String())
+	}
+	if i.FloatingIP.Server != nil && i.FloatingIP.Server.Name != "" {
+		terms = append(terms, i.FloatingIP.Server.Name)
+	}
+	return append(terms, resource.GetLabelTerms(i.FloatingIP.Labels)...)
+}
+
+func LoadFloatingIPs(ctx context.Context, client *hcloud.Client, labelSelector string, serverIndex *resource.ServerIndexStore) tea.Cmd {
 	return func() tea.Msg {
-		ctx := context.Background()
-		floatingIPs, err := client.FloatingIP.All(ctx)
+		floatingIPs, err := client.FloatingIP.AllWithOpts(ctx, hcloud.FloatingIPListOpts{
+			ListOpts: hcloud.ListOpts{LabelSelector: labelSelector},
+		})
 		if err != nil {
-			return message.ErrorMsg{err}
+			return message.ErrorMsg{Err: err}
 		}
 
-		for _, floatingIP := range floatingIPs {
-			if floatingIP.Server != nil {
-				server, _, err := client.Server.GetByID(ctx, floatingIP.Server.ID)
-				if err == nil && server != nil {
-					floatingIP.Server = server
+		// Populate Server name fields for assigned servers from a single server listing
+		if hasAssignedServer(floatingIPs) {
+			servers, err := serverIndex.Get(ctx, client)
+			if err == nil {
+				for _, floatingIP := range floatingIPs {
+					floatingIP.Server = servers.Resolve(floatingIP.Server)
 				}
 			}
 		}
@@ -59,6 +77,15 @@ func LoadFloatingIPs(client *hcloud.Client) tea.Cmd {
 	}
 }
 
+func hasAssignedServer(floatingIPs []*hcloud.FloatingIP) bool {
+	for _, floatingIP := range floatingIPs {
+		if floatingIP.Server != nil {
+			return true
+		}
+	}
+	return false
+}
+
 func floatingIPDisplayName(floatingIP *hcloud.FloatingIP) string {
 	if floatingIP == nil {
 		return "Unknown Floating IP"
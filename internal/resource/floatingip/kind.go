@@ -0,0 +1,93 @@
+package floatingip
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	ctm "github.com/grammeaway/lazyhetzner/internal/context_menu"
+	"github.com/grammeaway/lazyhetzner/internal/registry"
+	"github.com/grammeaway/lazyhetzner/internal/resource"
+	"github.com/grammeaway/lazyhetzner/internal/resource/actions"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+type kind struct{}
+
+func init() {
+	registry.Register(kind{})
+}
+
+func (kind) Type() resource.ResourceType { return resource.ResourceFloatingIPs }
+func (kind) Name() string                { return "Floating IPs" }
+func (kind) Key() string                 { return "floating_ips" }
+
+func (kind) Load(ctx context.Context, client *hcloud.Client, labelSelector string, serverIndex *resource.ServerIndexStore) tea.Cmd {
+	return LoadFloatingIPs(ctx, client, labelSelector, serverIndex)
+}
+
+func (kind) Items(msg tea.Msg) ([]list.Item, bool) {
+	loaded, ok := msg.(FloatingIPsLoadedMsg)
+	if !ok {
+		return nil, false
+	}
+	items := make([]list.Item, len(loaded.FloatingIPs))
+	for i, floatingIP := range loaded.FloatingIPs {
+		items[i] = FloatingIPItem{
+			FloatingIP:   floatingIP,
+			ResourceType: resource.ResourceFloatingIPs,
+			ResourceID:   floatingIP.ID,
+		}
+	}
+	return items, true
+}
+
+func (kind) ItemID(item list.Item) (int64, bool) {
+	if floatingIPItem, ok := item.(FloatingIPItem); ok {
+		return floatingIPItem.FloatingIP.ID, true
+	}
+	return 0, false
+}
+
+func (kind) Labels(item list.Item) map[string]string {
+	if floatingIPItem, ok := item.(FloatingIPItem); ok {
+		return getFloatingIPLabels(floatingIPItem.FloatingIP)
+	}
+	return nil
+}
+
+func (kind) Fingerprint(item list.Item) string {
+	floatingIPItem, ok := item.(FloatingIPItem)
+	if !ok {
+		return ""
+	}
+	parts := []string{floatingIPItem.FloatingIP.IP.String()}
+	if floatingIPItem.FloatingIP.Server != nil {
+		parts = append(parts, fmt.Sprintf("server=%d", floatingIPItem.FloatingIP.Server.ID))
+	}
+	return strings.Join(parts, "|")
+}
+
+func (kind) ContextMenu(item list.Item) (ctm.ContextMenu, bool) {
+	if floatingIPItem, ok := item.(FloatingIPItem); ok {
+		return CreateFloatingIPContextMenu(floatingIPItem.FloatingIP), true
+	}
+	return ctm.ContextMenu{}, false
+}
+
+func (kind) ExecuteAction(ctx context.Context, action string, item list.Item, env registry.ActionEnv) tea.Cmd {
+	if floatingIPItem, ok := item.(FloatingIPItem); ok {
+		if action == actions.HistoryMenuItem.Action {
+			return actions.LoadResourceActions(ctx, env.Client, resource.ResourceFloatingIPs, floatingIPItem.FloatingIP.ID, floatingIPDisplayName(floatingIPItem.FloatingIP))
+		}
+		return ExecuteFloatingIPContextAction(action, floatingIPItem.FloatingIP)
+	}
+	return nil
+}
+
+// Floating IPs have no detail view
+func (kind) Detail(tea.Msg) (registry.Detail, bool) {
+	return registry.Detail{}, false
+}
@@ -0,0 +1,40 @@
+package loadbalancer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/grammeaway/lazyhetzner/internal/registry"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+func (kind) ItemName(item list.Item) string {
+	if i, ok := item.(LoadBalancerItem); ok {
+		return i.Lb.Name
+	}
+	return ""
+}
+
+func (kind) GetLabels(ctx context.Context, client *hcloud.Client, id int64) (map[string]string, error) {
+	lb, _, err := client.LoadBalancer.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if lb == nil {
+		return nil, fmt.Errorf("load balancer %d not found", id)
+	}
+	return lb.Labels, nil
+}
+
+func (kind) UpdateLabels(ctx context.Context, client *hcloud.Client, id int64, labels map[string]string) error {
+	_, _, err := client.LoadBalancer.Update(ctx, &hcloud.LoadBalancer{ID: id}, hcloud.LoadBalancerUpdateOpts{Labels: labels})
+	return err
+}
+
+func (kind) Delete(ctx context.Context, client *hcloud.Client, id int64) error {
+	_, err := client.LoadBalancer.Delete(ctx, &hcloud.LoadBalancer{ID: id})
+	return err
+}
+
+func (kind) BulkActions() []registry.BulkAction { return nil }
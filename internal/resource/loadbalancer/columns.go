@@ -0,0 +1,84 @@
+package loadbalancer
+
+import (
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/grammeaway/lazyhetzner/internal/registry"
+	"github.com/grammeaway/lazyhetzner/internal/resource"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+func (kind) Columns() []registry.Column {
+	return []registry.Column{
+		{Key: "name", Title: "Name", Width: 24, Value: loadBalancerValue(func(lb *hcloud.LoadBalancer) string { return lb.Name })},
+		{
+			Key: "type", Title: "Type", Width: 8,
+			Value: loadBalancerValue(func(lb *hcloud.LoadBalancer) string {
+				if lb.LoadBalancerType == nil {
+					return "n/a"
+				}
+				return lb.LoadBalancerType.Name
+			}),
+		},
+		{
+			Key: "location", Title: "Location", Width: 8,
+			Value: loadBalancerValue(func(lb *hcloud.LoadBalancer) string {
+				if lb.Location == nil {
+					return "n/a"
+				}
+				return lb.Location.Name
+			}),
+		},
+		{
+			Key: "ipv4", Title: "IPv4", Width: 15,
+			Value: loadBalancerValue(func(lb *hcloud.LoadBalancer) string { return resource.FormatIP(getPublicIPv4(lb)) }),
+			Less:  loadBalancerLess(func(a, b *hcloud.LoadBalancer) bool { return resource.LessIP(getPublicIPv4(a), getPublicIPv4(b)) }),
+		},
+		{
+			Key: "targets", Title: "Targets", Width: 7,
+			Value: loadBalancerValue(func(lb *hcloud.LoadBalancer) string { return strconv.Itoa(len(lb.Targets)) }),
+			Less:  loadBalancerLess(func(a, b *hcloud.LoadBalancer) bool { return len(a.Targets) < len(b.Targets) }),
+		},
+		{
+			Key: "services", Title: "Services", Width: 8,
+			Value: loadBalancerValue(func(lb *hcloud.LoadBalancer) string { return strconv.Itoa(len(lb.Services)) }),
+			Less:  loadBalancerLess(func(a, b *hcloud.LoadBalancer) bool { return len(a.Services) < len(b.Services) }),
+		},
+		{Key: "labels", Title: "Labels", Width: 30, Value: loadBalancerValue(func(lb *hcloud.LoadBalancer) string { return resource.FormatLabels(lb.Labels) })},
+	}
+}
+
+func loadBalancerValue(value func(*hcloud.LoadBalancer) string) func(list.Item) string {
+	return func(item list.Item) string {
+		if lbItem, ok := item.(LoadBalancerItem); ok {
+			return value(lbItem.Lb)
+		}
+		return ""
+	}
+}
+
+func loadBalancerLess(less func(a, b *hcloud.LoadBalancer) bool) func(a, b list.Item) bool {
+	return func(a, b list.Item) bool {
+		lbA, okA := a.(LoadBalancerItem)
+		lbB, okB := b.(LoadBalancerItem)
+		return okA && okB && less(lbA.Lb, lbB.Lb)
+	}
+}
+
+// getPublicIPv4 returns the public IPv4 of a load balancer, or nil if it is private only
+func getPublicIPv4(lb *hcloud.LoadBalancer) net.IP {
+	if !lb.PublicNet.Enabled {
+		return nil
+	}
+	return lb.PublicNet.IPv4.IP
+}
+
+func (kind) SortKeys() []registry.SortKey {
+	return []registry.SortKey{
+		{Key: "name", Title: "name", Less: loadBalancerLess(func(a, b *hcloud.LoadBalancer) bool { return strings.ToLower(a.Name) < strings.ToLower(b.Name) })},
+		{Key: "targets", Title: "target count", Less: loadBalancerLess(func(a, b *hcloud.LoadBalancer) bool { return len(a.Targets) < len(b.Targets) })},
+	}
+}
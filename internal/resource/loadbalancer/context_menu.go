@@ -3,12 +3,12 @@ package loadbalancer
 import (
 	"fmt"
 	ctm "github.com/grammeaway/lazyhetzner/internal/context_menu"
-	r_lb "github.com/grammeaway/lazyhetzner/internal/resource/loadbalancer"
 	"github.com/grammeaway/lazyhetzner/internal/message"
 	"github.com/grammeaway/lazyhetzner/internal/resource"
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/atotto/clipboard"
+	"github.com/grammeaway/lazyhetzner/internal/resource/actions"
 	"github.com/grammeaway/lazyhetzner/internal/resource/label"
 )
 
@@ -38,7 +38,9 @@ func getLoadbalancerLabels(loadbalancer *hcloud.LoadBalancer) map[string]string
 func getLoadbalancerMenuItems() []ctm.ContextMenuItem {
 	return []ctm.ContextMenuItem{
 		{Label: "❌ Cancel", Action: "cancel"},
+		{Label: "🔎 View Details & Metrics", Action: "view_details"},
 		{Label: "🔖 View Labels", Action: "view_labels"},
+		actions.HistoryMenuItem,
 		{Label: "📋 Copy Loadbalancer ID", Action: "copy_id"},
 		{Label: "📋 Copy Loadbalancer Name", Action: "copy_name"},
 		{Label: "📋 Copy Public IP (IPv4)", Action: "copy_public_ip"},
@@ -138,18 +140,22 @@ func ExecuteLoadbalancerContextAction(selectedAction string, loadbalancer *hclou
 			if len(targets) == 0 {
 				return message.StatusMsg("No targets found for this loadbalancer.")
 			}
-			return r_lb.ViewLoadbalancerTargetsMsg{
+			return ViewLoadbalancerTargetsMsg{
 				LoadBalancer: loadbalancer,
 				Targets:      targets,
 			}
 		}
+	case "view_details":
+		return func() tea.Msg {
+			return ViewLoadbalancerDetailsMsg{LoadBalancer: loadbalancer}
+		}
 	case "view_services":
 		return func() tea.Msg {
 			services := loadbalancer.Services
 			if len(services) == 0 {
 				return message.StatusMsg("No services found for this loadbalancer.")
 			}	
-			return r_lb.ViewLoadbalancerServicesMsg{
+			return ViewLoadbalancerServicesMsg{
 			LoadBalancer: loadbalancer,
 			Services:     services,
 			}
@@ -0,0 +1,140 @@
+package loadbalancer
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/grammeaway/lazyhetzner/internal/registry"
+	"github.com/grammeaway/lazyhetzner/internal/resource"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+func (kind) Preview(item list.Item) (registry.Detail, bool) {
+	lbItem, ok := item.(LoadBalancerItem)
+	if !ok {
+		return registry.Detail{}, false
+	}
+	return getLoadBalancerDetail(lbItem.Lb), true
+}
+
+func getLoadBalancerDetail(lb *hcloud.LoadBalancer) registry.Detail {
+	lbType, location := "n/a", "n/a"
+	if lb.LoadBalancerType != nil {
+		lbType = lb.LoadBalancerType.Name
+	}
+	if lb.Location != nil {
+		location = lb.Location.Name
+	}
+
+	networkLines := []string{"Public Network: disabled"}
+	if lb.PublicNet.Enabled {
+		networkLines = []string{
+			fmt.Sprintf("Public IPv4: %s", resource.FormatIP(lb.PublicNet.IPv4.IP)),
+			fmt.Sprintf("Public IPv6: %s", resource.FormatIP(lb.PublicNet.IPv6.IP)),
+		}
+	}
+	for _, privateNet := range lb.PrivateNet {
+		networkID := int64(0)
+		if privateNet.Network != nil {
+			networkID = privateNet.Network.ID
+		}
+		networkLines = append(networkLines, fmt.Sprintf("• Network %d | IP: %s", networkID, resource.FormatIP(privateNet.IP)))
+	}
+
+	targetLines, targetLinks := formatTargets(lb.Targets)
+
+	return registry.Detail{
+		Title:  "Load Balancer Details",
+		Name:   lb.Name,
+		Header: fmt.Sprintf("⚖️ Load Balancer: %s (ID: %d)", lb.Name, lb.ID),
+		Sections: []registry.DetailSection{
+			{
+				Title: "Overview",
+				Lines: []string{
+					fmt.Sprintf("Type: %s", lbType),
+					fmt.Sprintf("Location: %s", location),
+					fmt.Sprintf("Algorithm: %s", lb.Algorithm.Type),
+					fmt.Sprintf("Created: %s", resource.FormatTime(lb.Created)),
+					fmt.Sprintf("Delete Protection: %t", lb.Protection.Delete),
+				},
+			},
+			{Title: "Networking", Lines: networkLines},
+			{Title: "Services", Lines: formatServices(lb.Services)},
+			{Title: "Targets", Lines: targetLines, Links: targetLinks},
+			{Title: "Labels", Lines: resource.GetLabelLines(lb.Labels)},
+		},
+		Resource: registry.Link{ResourceType: resource.ResourceLoadBalancers, ID: lb.ID},
+	}
+}
+
+func formatServices(services []hcloud.LoadBalancerService) []string {
+	if len(services) == 0 {
+		return []string{"No services defined."}
+	}
+	lines := make([]string, 0, len(services))
+	for _, service := range services {
+		line := fmt.Sprintf("• %s %d -> %d", service.Protocol, service.ListenPort, service.DestinationPort)
+		if service.Proxyprotocol {
+			line += " (proxy protocol)"
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// formatTargets lists the targets of a load balancer, linking server targets to their view
+func formatTargets(targets []hcloud.LoadBalancerTarget) ([]string, map[int]registry.Link) {
+	if len(targets) == 0 {
+		return []string{"No targets defined."}, nil
+	}
+	lines := make([]string, 0, len(targets))
+	links := map[int]registry.Link{}
+	for _, target := range targets {
+		switch {
+		case target.Server != nil && target.Server.Server != nil:
+			links[len(lines)] = registry.Link{ResourceType: resource.ResourceServers, ID: target.Server.Server.ID}
+			lines = append(lines, fmt.Sprintf("• Server %s | %s", formatServerName(target.Server.Server), formatTargetHealth(target.HealthStatus)))
+		case target.LabelSelector != nil:
+			lines = append(lines, fmt.Sprintf("• Label Selector %s (%d targets)", target.LabelSelector.Selector, len(target.Targets)))
+			for _, resolved := range target.Targets {
+				if resolved.Server == nil || resolved.Server.Server == nil {
+					continue
+				}
+				links[len(lines)] = registry.Link{ResourceType: resource.ResourceServers, ID: resolved.Server.Server.ID}
+				lines = append(lines, fmt.Sprintf("  • Server %s | %s", formatServerName(resolved.Server.Server), formatTargetHealth(resolved.HealthStatus)))
+			}
+		case target.IP != nil:
+			lines = append(lines, fmt.Sprintf("• IP %s", target.IP.IP))
+		default:
+			lines = append(lines, fmt.Sprintf("• %s", target.Type))
+		}
+	}
+	return lines, links
+}
+
+// formatTargetHealth summarizes the health checks of a target, one status per service port
+func formatTargetHealth(statuses []hcloud.LoadBalancerTargetHealthStatus) string {
+	if len(statuses) == 0 {
+		return "health unknown"
+	}
+	parts := make([]string, 0, len(statuses))
+	for _, status := range statuses {
+		icon := "⚪"
+		switch status.Status {
+		case hcloud.LoadBalancerTargetHealthStatusStatusHealthy:
+			icon = "🟢"
+		case hcloud.LoadBalancerTargetHealthStatusStatusUnhealthy:
+			icon = "🔴"
+		}
+		parts = append(parts, fmt.Sprintf("%s %d %s", icon, status.ListenPort, status.Status))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func formatServerName(server *hcloud.Server) string {
+	if server.Name == "" {
+		return fmt.Sprintf("%d", server.ID)
+	}
+	return fmt.Sprintf("%s (ID: %d)", server.Name, server.ID)
+}
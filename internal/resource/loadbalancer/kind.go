@@ -0,0 +1,160 @@
+package loadbalancer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	ctm "github.com/grammeaway/lazyhetzner/internal/context_menu"
+	"github.com/grammeaway/lazyhetzner/internal/registry"
+	"github.com/grammeaway/lazyhetzner/internal/resource"
+	"github.com/grammeaway/lazyhetzner/internal/resource/actions"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+type kind struct{}
+
+func init() {
+	registry.Register(kind{})
+}
+
+func (kind) Type() resource.ResourceType { return resource.ResourceLoadBalancers }
+func (kind) Name() string                { return "Load Balancers" }
+func (kind) Key() string                 { return "load_balancers" }
+
+func (kind) Load(ctx context.Context, client *hcloud.Client, labelSelector string, _ *resource.ServerIndexStore) tea.Cmd {
+	return LoadLoadBalancers(ctx, client, labelSelector)
+}
+
+func (kind) Items(msg tea.Msg) ([]list.Item, bool) {
+	loaded, ok := msg.(LoadBalancersLoadedMsg)
+	if !ok {
+		return nil, false
+	}
+	items := make([]list.Item, len(loaded.LoadBalancers))
+	for i, lb := range loaded.LoadBalancers {
+		items[i] = LoadBalancerItem{
+			Lb:           lb,
+			ResourceType: resource.ResourceLoadBalancers,
+			ResourceID:   lb.ID,
+		}
+	}
+	return items, true
+}
+
+func (kind) ItemID(item list.Item) (int64, bool) {
+	if lbItem, ok := item.(LoadBalancerItem); ok {
+		return lbItem.Lb.ID, true
+	}
+	return 0, false
+}
+
+func (kind) Labels(item list.Item) map[string]string {
+	if lbItem, ok := item.(LoadBalancerItem); ok {
+		return getLoadbalancerLabels(lbItem.Lb)
+	}
+	return nil
+}
+
+func (kind) Fingerprint(item list.Item) string {
+	lbItem, ok := item.(LoadBalancerItem)
+	if !ok {
+		return ""
+	}
+	parts := []string{lbItem.Lb.PublicNet.IPv4.IP.String(), lbItem.Lb.PublicNet.IPv6.IP.String()}
+	for _, privateNet := range lbItem.Lb.PrivateNet {
+		parts = append(parts, privateNet.IP.String())
+	}
+	parts = append(parts, fmt.Sprintf("targets=%d", len(lbItem.Lb.Targets)))
+	return strings.Join(parts, "|")
+}
+
+func (kind) ContextMenu(item list.Item) (ctm.ContextMenu, bool) {
+	if lbItem, ok := item.(LoadBalancerItem); ok {
+		return CreateLoadbalancerContextMenu(lbItem.Lb), true
+	}
+	return ctm.ContextMenu{}, false
+}
+
+func (kind) ExecuteAction(ctx context.Context, action string, item list.Item, env registry.ActionEnv) tea.Cmd {
+	if lbItem, ok := item.(LoadBalancerItem); ok {
+		if action == actions.HistoryMenuItem.Action {
+			return actions.LoadResourceActions(ctx, env.Client, resource.ResourceLoadBalancers, lbItem.Lb.ID, lbItem.Lb.Name)
+		}
+		return ExecuteLoadbalancerContextAction(action, lbItem.Lb)
+	}
+	return nil
+}
+
+func (kind) Detail(msg tea.Msg) (registry.Detail, bool) {
+	switch msg := msg.(type) {
+	case ViewLoadbalancerDetailsMsg:
+		return getLoadBalancerDetail(msg.LoadBalancer), true
+	case ViewLoadbalancerTargetsMsg:
+		sections := make([]registry.DetailSection, 0, len(msg.Targets))
+		for i, target := range msg.Targets {
+			lines, links := formatTarget(target)
+			sections = append(sections, registry.DetailSection{
+				Title: fmt.Sprintf("🎯 Target %d", i+1),
+				Lines: lines,
+				Links: links,
+			})
+		}
+		return registry.Detail{
+			Title:    "Load Balancer Targets",
+			Name:     msg.LoadBalancer.Name + " targets",
+			Header:   fmt.Sprintf("🎯 Targets for Load Balancer: %s", msg.LoadBalancer.Name),
+			Sections: sections,
+			Empty:    "⚠️  No targets found for this Load Balancer",
+		}, true
+	case ViewLoadbalancerServicesMsg:
+		sections := make([]registry.DetailSection, 0, len(msg.Services))
+		for i, service := range msg.Services {
+			sections = append(sections, registry.DetailSection{
+				Title: fmt.Sprintf("🔌 Service %d", i+1),
+				Lines: []string{
+					fmt.Sprintf("Protocol: %s", service.Protocol),
+					fmt.Sprintf("Port: %d -> %d", service.ListenPort, service.DestinationPort),
+					fmt.Sprintf("Proxy Protocol: %t", service.Proxyprotocol),
+				},
+			})
+		}
+		return registry.Detail{
+			Title:    "Load Balancer Services",
+			Name:     msg.LoadBalancer.Name + " services",
+			Header:   fmt.Sprintf("🔌 Services for Load Balancer: %s", msg.LoadBalancer.Name),
+			Sections: sections,
+			Empty:    "⚠️  No services found for this Load Balancer",
+		}, true
+	default:
+		return registry.Detail{}, false
+	}
+}
+
+// formatTarget describes a target, linking the servers it points at to their view.
+// Label selector targets list the servers they resolved to.
+func formatTarget(target hcloud.LoadBalancerTarget) ([]string, map[int]registry.Link) {
+	lines := []string{fmt.Sprintf("Type: %s", target.Type)}
+	links := map[int]registry.Link{}
+	switch {
+	case target.Server != nil && target.Server.Server != nil:
+		links[len(lines)] = registry.Link{ResourceType: resource.ResourceServers, ID: target.Server.Server.ID}
+		lines = append(lines, fmt.Sprintf("Server: %s (ID: %d)", target.Server.Server.Name, target.Server.Server.ID))
+		lines = append(lines, fmt.Sprintf("Health: %s", formatTargetHealth(target.HealthStatus)))
+	case target.LabelSelector != nil:
+		lines = append(lines, fmt.Sprintf("Label Selector: %s", target.LabelSelector.Selector))
+		lines = append(lines, fmt.Sprintf("Target count: %d", len(target.Targets)))
+		for _, resolved := range target.Targets {
+			if resolved.Server == nil || resolved.Server.Server == nil {
+				continue
+			}
+			links[len(lines)] = registry.Link{ResourceType: resource.ResourceServers, ID: resolved.Server.Server.ID}
+			lines = append(lines, fmt.Sprintf("• Server %s", formatServerName(resolved.Server.Server)))
+		}
+	case target.IP != nil:
+		lines = append(lines, fmt.Sprintf("IP: %s", target.IP.IP))
+	}
+	return lines, links
+}
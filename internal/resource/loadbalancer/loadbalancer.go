@@ -3,6 +3,7 @@ package loadbalancer
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
 	"github.com/grammeaway/lazyhetzner/internal/message"
@@ -16,6 +17,10 @@ type LoadBalancersLoadedMsg struct {
 }
 
 
+type ViewLoadbalancerDetailsMsg struct {
+	LoadBalancer *hcloud.LoadBalancer
+}
+
 type ViewLoadbalancerTargetsMsg struct {
 	LoadBalancer *hcloud.LoadBalancer
 	Targets []hcloud.LoadBalancerTarget
@@ -43,14 +48,40 @@ func (i LoadBalancerItem) Description() string {
 	return fmt.Sprintf("%s | Private only | Targets: %d | Services: %d", status, len(i.Lb.Targets), len(i.Lb.Services))
 }
 
+// SearchTerms returns the strings the global search matches a load balancer against
+func (i LoadBalancerItem) SearchTerms() []string {
+	terms := []string{i.Lb.Name, strconv.FormatInt(i.Lb.ID, 10)}
+	if i.Lb.PublicNet.IPv4.IP != nil {
+		terms = append(terms, i.Lb.PublicNet.IPv4.IP.String())
+	}
+	if i.Lb.PublicNet.IPv6.IP != nil {
+		terms = append(terms, i.Lb.PublicNet.IPv6.IP.String())
+	}
+	for _, privateNet := range i.Lb.PrivateNet {
+		if privateNet.IP != nil {
+			terms = append(terms, privateNet.IP.String())
+		}
+	}
+	for _, target := range i.Lb.Targets {
+		if target.Server != nil && target.Server.Server != nil && target.Server.Server.Name != "" {
+			terms = append(terms, target.Server.Server.Name)
+		}
+		if target.IP != nil {
+			terms = append(terms, target.IP.IP)
+		}
+	}
+	return append(terms, resource.GetLabelTerms(i.Lb.Labels)...)
+}
+
 
 
-func LoadLoadBalancers(client *hcloud.Client) tea.Cmd {
+func LoadLoadBalancers(ctx context.Context, client *hcloud.Client, labelSelector string) tea.Cmd {
 	return func() tea.Msg {
-		ctx := context.Background()
-		loadBalancers, err := client.LoadBalancer.All(ctx)
+		loadBalancers, err := client.LoadBalancer.AllWithOpts(ctx, hcloud.LoadBalancerListOpts{
+			ListOpts: hcloud.ListOpts{LabelSelector: labelSelector},
+		})
 		if err != nil {
-			return message.ErrorMsg{err}
+			return message.ErrorMsg{Err: err}
 		}
 		return LoadBalancersLoadedMsg{LoadBalancers: loadBalancers}
 	}
@@ -0,0 +1,63 @@
+package loadbalancer
+
+import (
+	"context"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/grammeaway/lazyhetzner/internal/metrics"
+	"github.com/grammeaway/lazyhetzner/internal/resource"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+// loadBalancerSeries are the time series charted for load balancers, in the order they are shown
+var loadBalancerSeries = []struct {
+	key   string
+	title string
+	unit  metrics.Unit
+}{
+	{"open_connections", "Open conns", metrics.UnitNone},
+	{"connections_per_second", "New conns", metrics.UnitPerSecond},
+	{"requests_per_second", "Requests", metrics.UnitPerSecond},
+	{"bandwidth.in", "Bandwidth in", metrics.UnitBytesPerSecond},
+	{"bandwidth.out", "Bandwidth out", metrics.UnitBytesPerSecond},
+}
+
+func (kind) LoadMetrics(ctx context.Context, client *hcloud.Client, item list.Item, window metrics.Window) tea.Cmd {
+	lbItem, ok := item.(LoadBalancerItem)
+	if !ok {
+		return nil
+	}
+	lb := lbItem.Lb
+	return func() tea.Msg {
+		loaded := metrics.LoadedMsg{ResourceType: resource.ResourceLoadBalancers, ID: lb.ID, Window: window}
+		start, end := window.Range()
+		lbMetrics, _, err := client.LoadBalancer.GetMetrics(ctx, lb, hcloud.LoadBalancerGetMetricsOpts{
+			Types: []hcloud.LoadBalancerMetricType{
+				hcloud.LoadBalancerMetricOpenConnections,
+				hcloud.LoadBalancerMetricConnectionsPerSecond,
+				hcloud.LoadBalancerMetricRequestsPerSecond,
+				hcloud.LoadBalancerMetricBandwidth,
+			},
+			Start: start,
+			End:   end,
+			Step:  window.Step(),
+		})
+		if err != nil {
+			loaded.Err = err
+			return loaded
+		}
+		for _, series := range loadBalancerSeries {
+			values, exists := lbMetrics.TimeSeries[series.key]
+			if !exists {
+				continue
+			}
+			parsed := make([]float64, len(values))
+			for i, value := range values {
+				parsed[i] = metrics.ParseValue(value.Value)
+			}
+			loaded.Series = append(loaded.Series, metrics.Series{Title: series.title, Unit: series.unit, Values: parsed})
+		}
+		return loaded
+	}
+}
@@ -0,0 +1,40 @@
+package network
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/grammeaway/lazyhetzner/internal/registry"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+func (kind) ItemName(item list.Item) string {
+	if i, ok := item.(NetworkItem); ok {
+		return i.Network.Name
+	}
+	return ""
+}
+
+func (kind) GetLabels(ctx context.Context, client *hcloud.Client, id int64) (map[string]string, error) {
+	network, _, err := client.Network.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if network == nil {
+		return nil, fmt.Errorf("network %d not found", id)
+	}
+	return network.Labels, nil
+}
+
+func (kind) UpdateLabels(ctx context.Context, client *hcloud.Client, id int64, labels map[string]string) error {
+	_, _, err := client.Network.Update(ctx, &hcloud.Network{ID: id}, hcloud.NetworkUpdateOpts{Labels: labels})
+	return err
+}
+
+func (kind) Delete(ctx context.Context, client *hcloud.Client, id int64) error {
+	_, err := client.Network.Delete(ctx, &hcloud.Network{ID: id})
+	return err
+}
+
+func (kind) BulkActions() []registry.BulkAction { return nil }
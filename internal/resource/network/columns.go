@@ -0,0 +1,63 @@
+package network
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/grammeaway/lazyhetzner/internal/registry"
+	"github.com/grammeaway/lazyhetzner/internal/resource"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+func (kind) Columns() []registry.Column {
+	return []registry.Column{
+		{Key: "name", Title: "Name", Width: 24, Value: networkValue(func(n *hcloud.Network) string { return n.Name })},
+		{
+			Key: "ip_range", Title: "IP Range", Width: 18,
+			Value: networkValue(func(n *hcloud.Network) string {
+				if n.IPRange == nil {
+					return "n/a"
+				}
+				return n.IPRange.String()
+			}),
+			Less: networkLess(func(a, b *hcloud.Network) bool {
+				return a.IPRange != nil && (b.IPRange == nil || resource.LessIP(a.IPRange.IP, b.IPRange.IP))
+			}),
+		},
+		{
+			Key: "subnets", Title: "Subnets", Width: 7,
+			Value: networkValue(func(n *hcloud.Network) string { return strconv.Itoa(len(n.Subnets)) }),
+			Less:  networkLess(func(a, b *hcloud.Network) bool { return len(a.Subnets) < len(b.Subnets) }),
+		},
+		{
+			Key: "servers", Title: "Servers", Width: 7,
+			Value: networkValue(func(n *hcloud.Network) string { return strconv.Itoa(len(n.Servers)) }),
+			Less:  networkLess(func(a, b *hcloud.Network) bool { return len(a.Servers) < len(b.Servers) }),
+		},
+		{Key: "labels", Title: "Labels", Width: 30, Value: networkValue(func(n *hcloud.Network) string { return resource.FormatLabels(n.Labels) })},
+	}
+}
+
+func networkValue(value func(*hcloud.Network) string) func(list.Item) string {
+	return func(item list.Item) string {
+		if networkItem, ok := item.(NetworkItem); ok {
+			return value(networkItem.Network)
+		}
+		return ""
+	}
+}
+
+func networkLess(less func(a, b *hcloud.Network) bool) func(a, b list.Item) bool {
+	return func(a, b list.Item) bool {
+		networkA, okA := a.(NetworkItem)
+		networkB, okB := b.(NetworkItem)
+		return okA && okB && less(networkA.Network, networkB.Network)
+	}
+}
+
+func (kind) SortKeys() []registry.SortKey {
+	return []registry.SortKey{
+		{Key: "name", Title: "name", Less: networkLess(func(a, b *hcloud.Network) bool { return strings.ToLower(a.Name) < strings.ToLower(b.Name) })},
+	}
+}
@@ -7,8 +7,8 @@ import (
 	ctm "github.com/grammeaway/lazyhetzner/internal/context_menu"
 	"github.com/grammeaway/lazyhetzner/internal/message"
 	"github.com/grammeaway/lazyhetzner/internal/resource"
+	"github.com/grammeaway/lazyhetzner/internal/resource/actions"
 	"github.com/grammeaway/lazyhetzner/internal/resource/label"
-	r_n "github.com/grammeaway/lazyhetzner/internal/resource/network"
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
 )
 
@@ -36,6 +36,7 @@ func getNetworkMenuItems() []ctm.ContextMenuItem {
 		{Label: "❌ Cancel", Action: "cancel"},
 		{Label: "🧩 View Subnets", Action: "view_subnets"},
 		{Label: "🔖 View Labels", Action: "view_labels"},
+		actions.HistoryMenuItem,
 		// Copy the network ID to clipboard
 		{Label: "📋 Copy Network ID", Action: "copy_id"},
 		// Copy the network name to clipboard
@@ -58,7 +59,7 @@ func ExecuteNetworkContextAction(selectedAction string, network *hcloud.Network)
 			}
 		}
 		return func() tea.Msg {
-			return r_n.ViewNetworkSubnetsMsg{
+			return ViewNetworkSubnetsMsg{
 				Network: network,
 				Subnets: network.Subnets,
 			}
@@ -0,0 +1,142 @@
+package network
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/grammeaway/lazyhetzner/internal/registry"
+	"github.com/grammeaway/lazyhetzner/internal/resource"
+	"github.com/grammeaway/lazyhetzner/internal/resource/server"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+func (kind) Preview(item list.Item) (registry.Detail, bool) {
+	networkItem, ok := item.(NetworkItem)
+	if !ok {
+		return registry.Detail{}, false
+	}
+	network := networkItem.Network
+	ipRange := "n/a"
+	if network.IPRange != nil {
+		ipRange = network.IPRange.String()
+	}
+	subnetLines, subnetLinks := formatSubnets(network)
+	return registry.Detail{
+		Title:  "Network Details",
+		Name:   network.Name,
+		Header: fmt.Sprintf("🌐 Network: %s (ID: %d)", network.Name, network.ID),
+		Sections: []registry.DetailSection{
+			{
+				Title: "Overview",
+				Lines: []string{
+					fmt.Sprintf("IP Range: %s", ipRange),
+					fmt.Sprintf("Created: %s", resource.FormatTime(network.Created)),
+					fmt.Sprintf("Routes exposed to vSwitch: %t", network.ExposeRoutesToVSwitch),
+					fmt.Sprintf("Delete Protection: %t", network.Protection.Delete),
+				},
+			},
+			{Title: "Subnets", Lines: subnetLines, Links: subnetLinks},
+			{Title: "Routes", Lines: formatRoutes(network.Routes)},
+			{
+				Title: "Attached",
+				Lines: []string{
+					fmt.Sprintf("Servers: %d", len(network.Servers)),
+					fmt.Sprintf("Load Balancers: %d", len(network.LoadBalancers)),
+				},
+			},
+			{Title: "Labels", Lines: resource.GetLabelLines(network.Labels)},
+		},
+	}, true
+}
+
+// formatSubnets lists the subnets of a network, linking each to its view
+func formatSubnets(network *hcloud.Network) ([]string, map[int]registry.Link) {
+	if len(network.Subnets) == 0 {
+		return []string{"No subnets defined."}, nil
+	}
+	lines := make([]string, 0, len(network.Subnets))
+	links := map[int]registry.Link{}
+	for _, subnet := range network.Subnets {
+		ipRange := "n/a"
+		if subnet.IPRange != nil {
+			ipRange = subnet.IPRange.String()
+			links[len(lines)] = registry.Link{ResourceType: resource.ResourceNetworks, ID: network.ID, Part: resource.SubnetPart(ipRange)}
+		}
+		lines = append(lines, fmt.Sprintf("• %s (%s) | Zone: %s | Gateway: %s", ipRange, strings.ToUpper(string(subnet.Type)), subnet.NetworkZone, resource.FormatIP(subnet.Gateway)))
+	}
+	return lines, links
+}
+
+func formatRoutes(routes []hcloud.NetworkRoute) []string {
+	if len(routes) == 0 {
+		return []string{"No routes defined."}
+	}
+	lines := make([]string, 0, len(routes))
+	for _, route := range routes {
+		destination := "n/a"
+		if route.Destination != nil {
+			destination = route.Destination.String()
+		}
+		lines = append(lines, fmt.Sprintf("• %s via %s", destination, resource.FormatIP(route.Gateway)))
+	}
+	return lines
+}
+
+// PartDetail shows a subnet of a network, with the servers that have an IP in it
+func (kind) PartDetail(item list.Item, part string, related registry.Related) (registry.Detail, bool) {
+	networkItem, ok := item.(NetworkItem)
+	if !ok {
+		return registry.Detail{}, false
+	}
+	network := networkItem.Network
+	for _, subnet := range network.Subnets {
+		if subnet.IPRange == nil || resource.SubnetPart(subnet.IPRange.String()) != part {
+			continue
+		}
+		serverLines, serverLinks := formatSubnetServers(network, subnet, related(resource.ResourceServers))
+		return registry.Detail{
+			Title:  "Subnet Details",
+			Name:   subnet.IPRange.String(),
+			Header: fmt.Sprintf("🧩 Subnet %s of Network: %s", subnet.IPRange.String(), network.Name),
+			Sections: []registry.DetailSection{
+				{
+					Title: "Overview",
+					Lines: []string{
+						fmt.Sprintf("IP Range: %s", subnet.IPRange.String()),
+						fmt.Sprintf("Type: %s", strings.ToUpper(string(subnet.Type))),
+						fmt.Sprintf("Network Zone: %s", subnet.NetworkZone),
+						fmt.Sprintf("Gateway: %s", resource.FormatIP(subnet.Gateway)),
+						fmt.Sprintf("Network: %s (ID: %d)", network.Name, network.ID),
+					},
+					Links: map[int]registry.Link{4: {ResourceType: resource.ResourceNetworks, ID: network.ID}},
+				},
+				{Title: "Servers", Lines: serverLines, Links: serverLinks},
+			},
+		}, true
+	}
+	return registry.Detail{}, false
+}
+
+// formatSubnetServers lists the loaded servers attached to the network with an IP in the subnet, linking each to its view
+func formatSubnetServers(network *hcloud.Network, subnet hcloud.NetworkSubnet, servers []list.Item) ([]string, map[int]registry.Link) {
+	lines := []string{}
+	links := map[int]registry.Link{}
+	for _, item := range servers {
+		serverItem, ok := item.(server.ServerItem)
+		if !ok {
+			continue
+		}
+		for _, privateNet := range serverItem.Server.PrivateNet {
+			if privateNet.Network == nil || privateNet.Network.ID != network.ID || !subnet.IPRange.Contains(privateNet.IP) {
+				continue
+			}
+			links[len(lines)] = registry.Link{ResourceType: resource.ResourceServers, ID: serverItem.Server.ID}
+			lines = append(lines, fmt.Sprintf("• %s | IP: %s", serverItem.Server.Name, resource.FormatIP(privateNet.IP)))
+		}
+	}
+	if len(lines) == 0 {
+		return []string{"No servers in this subnet."}, nil
+	}
+	return lines, links
+}
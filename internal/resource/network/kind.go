@@ -0,0 +1,117 @@
+package network
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	ctm "github.com/grammeaway/lazyhetzner/internal/context_menu"
+	"github.com/grammeaway/lazyhetzner/internal/registry"
+	"github.com/grammeaway/lazyhetzner/internal/resource"
+	"github.com/grammeaway/lazyhetzner/internal/resource/actions"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+type kind struct{}
+
+func init() {
+	registry.Register(kind{})
+}
+
+func (kind) Type() resource.ResourceType { return resource.ResourceNetworks }
+func (kind) Name() string                { return "Networks" }
+func (kind) Key() string                 { return "networks" }
+
+func (kind) Load(ctx context.Context, client *hcloud.Client, labelSelector string, _ *resource.ServerIndexStore) tea.Cmd {
+	return LoadNetworks(ctx, client, labelSelector)
+}
+
+func (kind) Items(msg tea.Msg) ([]list.Item, bool) {
+	loaded, ok := msg.(NetworksLoadedMsg)
+	if !ok {
+		return nil, false
+	}
+	items := make([]list.Item, len(loaded.Networks))
+	for i, network := range loaded.Networks {
+		items[i] = NetworkItem{
+			Network:      network,
+			ResourceType: resource.ResourceNetworks,
+			ResourceID:   network.ID,
+		}
+	}
+	return items, true
+}
+
+func (kind) ItemID(item list.Item) (int64, bool) {
+	if networkItem, ok := item.(NetworkItem); ok {
+		return networkItem.Network.ID, true
+	}
+	return 0, false
+}
+
+func (kind) Labels(item list.Item) map[string]string {
+	if networkItem, ok := item.(NetworkItem); ok {
+		return getNetworkLabels(networkItem.Network)
+	}
+	return nil
+}
+
+func (kind) Fingerprint(item list.Item) string {
+	networkItem, ok := item.(NetworkItem)
+	if !ok {
+		return ""
+	}
+	parts := []string{}
+	if networkItem.Network.IPRange != nil {
+		parts = append(parts, networkItem.Network.IPRange.String())
+	}
+	for _, subnet := range networkItem.Network.Subnets {
+		if subnet.IPRange != nil {
+			parts = append(parts, subnet.IPRange.String())
+		}
+	}
+	return strings.Join(parts, "|")
+}
+
+func (kind) ContextMenu(item list.Item) (ctm.ContextMenu, bool) {
+	if networkItem, ok := item.(NetworkItem); ok {
+		return CreateNetworkContextMenu(networkItem.Network), true
+	}
+	return ctm.ContextMenu{}, false
+}
+
+func (kind) ExecuteAction(ctx context.Context, action string, item list.Item, env registry.ActionEnv) tea.Cmd {
+	if networkItem, ok := item.(NetworkItem); ok {
+		if action == actions.HistoryMenuItem.Action {
+			return actions.LoadResourceActions(ctx, env.Client, resource.ResourceNetworks, networkItem.Network.ID, networkItem.Network.Name)
+		}
+		return ExecuteNetworkContextAction(action, networkItem.Network)
+	}
+	return nil
+}
+
+func (kind) Detail(msg tea.Msg) (registry.Detail, bool) {
+	subnetsMsg, ok := msg.(ViewNetworkSubnetsMsg)
+	if !ok {
+		return registry.Detail{}, false
+	}
+	sections := make([]registry.DetailSection, 0, len(subnetsMsg.Subnets))
+	for i, subnet := range subnetsMsg.Subnets {
+		sections = append(sections, registry.DetailSection{
+			Title: fmt.Sprintf("🧩 Subnet %d: %s (%s)", i+1, subnet.IPRange.String(), strings.ToUpper(string(subnet.Type))),
+			Lines: []string{
+				fmt.Sprintf("Network Zone: %s", subnet.NetworkZone),
+				fmt.Sprintf("Gateway: %s", resource.FormatIP(subnet.Gateway)),
+			},
+		})
+	}
+	return registry.Detail{
+		Title:    "Network Subnets",
+		Name:     subnetsMsg.Network.Name + " subnets",
+		Header:   fmt.Sprintf("🧩 Subnets for Network: %s", subnetsMsg.Network.Name),
+		Sections: sections,
+		Empty:    "⚠️  No subnets found for this Network",
+	}, true
+}
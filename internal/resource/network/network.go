@@ -3,6 +3,8 @@ package network
 import (
 	"context"
 	"fmt"
+	"strconv"
+
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/grammeaway/lazyhetzner/internal/message"
 	"github.com/grammeaway/lazyhetzner/internal/resource"
@@ -31,12 +33,27 @@ func (i NetworkItem) Description() string {
 	return fmt.Sprintf("IP Range: %s | Subnets: %d", i.Network.IPRange.String(), len(i.Network.Subnets))
 }
 
-func LoadNetworks(client *hcloud.Client) tea.Cmd {
+// SearchTerms returns the strings the global search matches a network against
+func (i NetworkItem) SearchTerms() []string {
+	terms := []string{i.Network.Name, strconv.FormatInt(i.Network.ID, 10)}
+	if i.Network.IPRange != nil {
+		terms = append(terms, i.Network.IPRange.String())
+	}
+	for _, subnet := range i.Network.Subnets {
+		if subnet.IPRange != nil {
+			terms = append(terms, subnet.IPRange.String())
+		}
+	}
+	return append(terms, resource.GetLabelTerms(i.Network.Labels)...)
+}
+
+func LoadNetworks(ctx context.Context, client *hcloud.Client, labelSelector string) tea.Cmd {
 	return func() tea.Msg {
-		ctx := context.Background()
-		networks, err := client.Network.All(ctx)
+		networks, err := client.Network.AllWithOpts(ctx, hcloud.NetworkListOpts{
+			ListOpts: hcloud.ListOpts{LabelSelector: labelSelector},
+		})
 		if err != nil {
-			return message.ErrorMsg{err}
+			return message.ErrorMsg{Err: err}
 		}
 		return NetworksLoadedMsg{Networks: networks}
 	}
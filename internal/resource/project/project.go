@@ -24,6 +24,13 @@ func (i ProjectItem) Description() string {
 	if len(tokenPreview) > 16 {
 		tokenPreview = tokenPreview[:16] + "..."
 	}
+	// Tokens kept outside of config.json aren't previewed, only where they come from
+	switch i.Config.GetTokenBackend() {
+	case config.TokenBackendVault:
+		tokenPreview = "🔒 vault"
+	case config.TokenBackendCommand:
+		tokenPreview = "🔑 " + i.Config.TokenRef
+	}
 	if i.IsDefault {
 		return fmt.Sprintf("Token: %s (default project)", tokenPreview)
 	}
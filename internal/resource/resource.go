@@ -1,13 +1,14 @@
 package resource
 
 import (
-	"context"
+	"bytes"
 	"fmt"
-	tea "github.com/charmbracelet/bubbletea"
-	"github.com/grammeaway/lazyhetzner/internal/message"
-	"github.com/hetznercloud/hcloud-go/v2/hcloud"
-	"strconv"
+	"net"
+	"sort"
 	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
 )
 
 type ResourceType int
@@ -19,79 +20,64 @@ const (
 	ResourceFloatingIPs
 	ResourceFirewalls
 	ResourceVolumes
+	ResourceActions
 )
 
+// Names and config keys of the resource types, filled in as the resource packages register them
+var (
+	typeNames = map[ResourceType]string{}
+	typeKeys  = map[ResourceType]string{}
+)
+
+// RegisterType records the name and config key of a resource type
+func RegisterType(rt ResourceType, name string, key string) {
+	typeNames[rt] = name
+	typeKeys[rt] = key
+}
+
 func GetResourceNameFromType(rt ResourceType) string {
-	switch rt {
-	case ResourceServers:
-		return "Servers"
-	case ResourceNetworks:
-		return "Networks"
-	case ResourceLoadBalancers:
-		return "Load Balancers"
-	case ResourceFloatingIPs:
-		return "Floating IPs"
-	case ResourceFirewalls:
-		return "Firewalls"
-	case ResourceVolumes:
-		return "Volumes"
-	default:
-		return "Unknown Resource"
+	if name, exists := typeNames[rt]; exists {
+		return name
 	}
+	return "Unknown Resource"
 }
 
-func loadResources(client *hcloud.Client) tea.Cmd {
-	return func() tea.Msg {
-		ctx := context.Background()
-
-		servers, err := client.Server.All(ctx)
-		if err != nil {
-			return message.ErrorMsg{err}
-		}
-
-		networks, err := client.Network.All(ctx)
-		if err != nil {
-			return message.ErrorMsg{err}
-		}
-
-		loadBalancers, err := client.LoadBalancer.All(ctx)
-		if err != nil {
-			return message.ErrorMsg{err}
-		}
-
-		floatingIPs, err := client.FloatingIP.All(ctx)
-		if err != nil {
-			return message.ErrorMsg{err}
-		}
-
-		firewalls, err := client.Firewall.All(ctx)
-		if err != nil {
-			return message.ErrorMsg{err}
-		}
-
-		volumes, err := client.Volume.All(ctx)
-		if err != nil {
-			return message.ErrorMsg{err}
-		}
-
-		return resourcesLoadedMsg{
-			servers:       servers,
-			networks:      networks,
-			loadBalancers: loadBalancers,
-			floatingIPs:   floatingIPs,
-			firewalls:     firewalls,
-			volumes:       volumes,
-		}
+// GetResourceKeyFromType returns a stable identifier for the resource type, used as key in the config file
+func GetResourceKeyFromType(rt ResourceType) string {
+	if key, exists := typeKeys[rt]; exists {
+		return key
+	}
+	return "unknown"
+}
+
+// GetLabelTerms returns labels as key=value strings, for use in search terms
+func GetLabelTerms(labels map[string]string) []string {
+	terms := make([]string, 0, len(labels))
+	for key, value := range labels {
+		terms = append(terms, fmt.Sprintf("%s=%s", key, value))
 	}
+	return terms
 }
 
-type resourcesLoadedMsg struct {
-	servers       []*hcloud.Server
-	networks      []*hcloud.Network
-	loadBalancers []*hcloud.LoadBalancer
-	floatingIPs   []*hcloud.FloatingIP
-	firewalls     []*hcloud.Firewall
-	volumes       []*hcloud.Volume
+// FormatLabels returns labels as key=value pairs sorted by key, for display on a single line
+func FormatLabels(labels map[string]string) string {
+	terms := GetLabelTerms(labels)
+	sort.Strings(terms)
+	return strings.Join(terms, ", ")
+}
+
+// GetLabelLines returns labels as key=value lines sorted by key, for use in detail sections
+func GetLabelLines(labels map[string]string) []string {
+	if len(labels) == 0 {
+		return []string{"No labels attached."}
+	}
+	terms := GetLabelTerms(labels)
+	sort.Strings(terms)
+	lines := make([]string, 0, len(terms))
+	for _, term := range terms {
+		lines = append(lines, "• "+term)
+	}
+	return lines
 }
 
 type ResourceLoadStartMsg struct {
@@ -104,82 +90,28 @@ func StartResourceLoad(rt ResourceType) tea.Cmd {
 	}
 }
 
-func getResourceLabels(client *hcloud.Client, resourceType ResourceType, resourceID int) tea.Cmd {
-	return func() tea.Msg {
-		ctx := context.Background()
-
-		var labels map[string]string
-
-		switch resourceType {
-		case ResourceServers:
-			server, _, err := client.Server.Get(ctx, strconv.Itoa(resourceID))
-			if err != nil {
-				return message.ErrorMsg{err}
-			}
-			if server == nil {
-				return message.ErrorMsg{fmt.Errorf("server with ID %d not found", strconv.Itoa(resourceID))}
-			}
-			labels = server.Labels
-
-		case ResourceNetworks:
-			network, _, err := client.Network.Get(ctx, strconv.Itoa(resourceID))
-			if err != nil {
-				return message.ErrorMsg{err}
-			}
-			if network == nil {
-				return message.ErrorMsg{fmt.Errorf("network with ID %d not found", resourceID)}
-			}
-			labels = network.Labels
-
-		case ResourceLoadBalancers:
-			lb, _, err := client.LoadBalancer.Get(ctx, strconv.Itoa(resourceID))
-			if err != nil {
-				return message.ErrorMsg{err}
-			}
-			if lb == nil {
-				return message.ErrorMsg{fmt.Errorf("load balancer with ID %d not found", resourceID)}
-			}
-			labels = lb.Labels
-
-		case ResourceFirewalls:
-			firewall, _, err := client.Firewall.GetByID(ctx, int64(resourceID))
-			if err != nil {
-				return message.ErrorMsg{err}
-			}
-			if firewall == nil {
-				return message.ErrorMsg{fmt.Errorf("firewall with ID %d not found", resourceID)}
-			}
-			labels = firewall.Labels
-
-		case ResourceFloatingIPs:
-			floatingIP, _, err := client.FloatingIP.Get(ctx, strconv.Itoa(resourceID))
-			if err != nil {
-				return message.ErrorMsg{err}
-			}
-			if floatingIP == nil {
-				return message.ErrorMsg{fmt.Errorf("floating IP with ID %d not found", resourceID)}
-			}
-			labels = floatingIP.Labels
-
-		case ResourceVolumes:
-			volume, _, err := client.Volume.Get(ctx, strconv.Itoa(resourceID))
-			if err != nil {
-				return message.ErrorMsg{err}
-			}
-			if volume == nil {
-				return message.ErrorMsg{fmt.Errorf("volume with ID %d not found", resourceID)}
-			}
-			labels = volume.Labels
-
-		default:
-			return message.ErrorMsg{fmt.Errorf("unknown resource type: %d", resourceType)}
-		}
-
-		labelList := make([]string, 0, len(labels))
-		for k, v := range labels {
-			labelList = append(labelList, fmt.Sprintf("%s: %s", k, v))
-		}
-
-		return message.StatusMsg(fmt.Sprintf("Labels for resource ID %d:\n%s", resourceID, strings.Join(labelList, "\n")))
+// SubnetPart names the subnet of a network with the given IP range, for links to the subnet's view
+func SubnetPart(ipRange string) string {
+	return "subnet:" + ipRange
+}
+
+// FormatIP returns an IP for display, or n/a if it is missing
+func FormatIP(ip net.IP) string {
+	if len(ip) == 0 {
+		return "n/a"
+	}
+	return ip.String()
+}
+
+// LessIP orders IPs numerically, with missing IPs first
+func LessIP(a, b net.IP) bool {
+	return bytes.Compare(a.To16(), b.To16()) < 0
+}
+
+// FormatTime returns a timestamp for display, or n/a if it is missing
+func FormatTime(t time.Time) string {
+	if t.IsZero() {
+		return "n/a"
 	}
+	return t.Format("2006-01-02 15:04:05")
 }
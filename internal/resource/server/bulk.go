@@ -0,0 +1,103 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/charmbracelet/bubbles/list"
+	ctm "github.com/grammeaway/lazyhetzner/internal/context_menu"
+	"github.com/grammeaway/lazyhetzner/internal/registry"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+func (kind) ItemName(item list.Item) string {
+	if serverItem, ok := item.(ServerItem); ok {
+		return serverItem.Server.Name
+	}
+	return ""
+}
+
+func (kind) GetLabels(ctx context.Context, client *hcloud.Client, id int64) (map[string]string, error) {
+	server, _, err := client.Server.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if server == nil {
+		return nil, fmt.Errorf("server %d not found", id)
+	}
+	return server.Labels, nil
+}
+
+func (kind) UpdateLabels(ctx context.Context, client *hcloud.Client, id int64, labels map[string]string) error {
+	_, _, err := client.Server.Update(ctx, &hcloud.Server{ID: id}, hcloud.ServerUpdateOpts{Labels: labels})
+	return err
+}
+
+func (kind) Delete(ctx context.Context, client *hcloud.Client, id int64) error {
+	result, _, err := client.Server.DeleteWithResult(ctx, &hcloud.Server{ID: id})
+	if err == nil && result != nil && result.Action != nil {
+		err = client.Action.WaitFor(ctx, result.Action)
+	}
+	return err
+}
+
+func (kind) BulkActions() []registry.BulkAction {
+	return []registry.BulkAction{
+		powerAction(ctm.ContextMenuItem{Label: "🟢 Power On", Action: "poweron", Mutating: true}, "Power on", (*hcloud.ServerClient).Poweron),
+		powerAction(ctm.ContextMenuItem{Label: "🟡 Shutdown", Action: "shutdown", Mutating: true, Destructive: true}, "Shutdown", (*hcloud.ServerClient).Shutdown),
+		powerAction(ctm.ContextMenuItem{Label: "🔄 Reboot", Action: "reboot", Mutating: true, Destructive: true}, "Reboot", (*hcloud.ServerClient).Reboot),
+		powerAction(ctm.ContextMenuItem{Label: "🔴 Power Off", Action: "poweroff", Mutating: true, Destructive: true}, "Power off", (*hcloud.ServerClient).Poweroff),
+		powerAction(ctm.ContextMenuItem{Label: "⚡ Reset", Action: "reset", Mutating: true, Destructive: true}, "Reset", (*hcloud.ServerClient).Reset),
+		{
+			Item:      ctm.ContextMenuItem{Label: "🧱 Apply Firewall", Action: "apply_firewall", Mutating: true},
+			Name:      "Apply firewall",
+			Prompt:    "Firewall name or ID:",
+			ParamName: "firewall",
+			Resolve:   resolveFirewall,
+			Run:       applyFirewall,
+		},
+	}
+}
+
+// powerAction runs a power change of the server client and waits for it to finish
+func powerAction(item ctm.ContextMenuItem, name string, change func(*hcloud.ServerClient, context.Context, *hcloud.Server) (*hcloud.Action, *hcloud.Response, error)) registry.BulkAction {
+	return registry.BulkAction{
+		Item: item,
+		Name: name,
+		Run: func(ctx context.Context, client *hcloud.Client, id int64, _ string) error {
+			action, _, err := change(&client.Server, ctx, &hcloud.Server{ID: id})
+			if err != nil {
+				return err
+			}
+			return client.Action.WaitFor(ctx, action)
+		},
+	}
+}
+
+// resolveFirewall looks up a firewall by name or ID once per project, returning its ID
+func resolveFirewall(ctx context.Context, client *hcloud.Client, param string) (string, error) {
+	firewall, _, err := client.Firewall.Get(ctx, param)
+	if err != nil {
+		return "", err
+	}
+	if firewall == nil {
+		return "", fmt.Errorf("firewall %s not found", param)
+	}
+	return strconv.FormatInt(firewall.ID, 10), nil
+}
+
+func applyFirewall(ctx context.Context, client *hcloud.Client, id int64, firewallID string) error {
+	fwID, err := strconv.ParseInt(firewallID, 10, 64)
+	if err != nil {
+		return err
+	}
+	actions, _, err := client.Firewall.ApplyResources(ctx, &hcloud.Firewall{ID: fwID}, []hcloud.FirewallResource{{
+		Type:   hcloud.FirewallResourceTypeServer,
+		Server: &hcloud.FirewallResourceServer{ID: id},
+	}})
+	if err != nil {
+		return err
+	}
+	return client.Action.WaitFor(ctx, actions...)
+}
@@ -0,0 +1,73 @@
+package server
+
+import (
+	"net"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/grammeaway/lazyhetzner/internal/registry"
+	"github.com/grammeaway/lazyhetzner/internal/resource"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+func (kind) Columns() []registry.Column {
+	return []registry.Column{
+		{Key: "name", Title: "Name", Width: 24, Value: serverValue(func(s *hcloud.Server) string { return s.Name })},
+		{Key: "status", Title: "Status", Width: 12, Value: serverValue(func(s *hcloud.Server) string { return string(s.Status) })},
+		{Key: "type", Title: "Type", Width: 10, Value: serverValue(formatServerType)},
+		{Key: "location", Title: "Location", Width: 8, Value: serverValue(formatServerLocation)},
+		{
+			Key: "ipv4", Title: "IPv4", Width: 15,
+			Value: serverValue(func(s *hcloud.Server) string { return resource.FormatIP(s.PublicNet.IPv4.IP) }),
+			Less:  serverLess(func(a, b *hcloud.Server) bool { return resource.LessIP(a.PublicNet.IPv4.IP, b.PublicNet.IPv4.IP) }),
+		},
+		{
+			Key: "private_ip", Title: "Private IP", Width: 15,
+			Value: serverValue(func(s *hcloud.Server) string { return resource.FormatIP(getPrivateIP(s)) }),
+			Less:  serverLess(func(a, b *hcloud.Server) bool { return resource.LessIP(getPrivateIP(a), getPrivateIP(b)) }),
+		},
+		{Key: "labels", Title: "Labels", Width: 30, Value: serverValue(func(s *hcloud.Server) string { return resource.FormatLabels(s.Labels) })},
+	}
+}
+
+func serverValue(value func(*hcloud.Server) string) func(list.Item) string {
+	return func(item list.Item) string {
+		if serverItem, ok := item.(ServerItem); ok {
+			return value(serverItem.Server)
+		}
+		return ""
+	}
+}
+
+func serverLess(less func(a, b *hcloud.Server) bool) func(a, b list.Item) bool {
+	return func(a, b list.Item) bool {
+		serverA, okA := a.(ServerItem)
+		serverB, okB := b.(ServerItem)
+		return okA && okB && less(serverA.Server, serverB.Server)
+	}
+}
+
+func formatServerLocation(server *hcloud.Server) string {
+	if server.Datacenter == nil || server.Datacenter.Location == nil {
+		return "n/a"
+	}
+	return server.Datacenter.Location.Name
+}
+
+// getPrivateIP returns the IP of the first private network the server is attached to
+func getPrivateIP(server *hcloud.Server) net.IP {
+	if len(server.PrivateNet) == 0 {
+		return nil
+	}
+	return server.PrivateNet[0].IP
+}
+
+func (kind) SortKeys() []registry.SortKey {
+	return []registry.SortKey{
+		{Key: "name", Title: "name", Less: serverLess(func(a, b *hcloud.Server) bool { return strings.ToLower(a.Name) < strings.ToLower(b.Name) })},
+		{Key: "status", Title: "status", Less: serverLess(func(a, b *hcloud.Server) bool { return a.Status < b.Status })},
+		{Key: "created", Title: "created", Less: serverLess(func(a, b *hcloud.Server) bool { return a.Created.Before(b.Created) })},
+		{Key: "type", Title: "type", Less: serverLess(func(a, b *hcloud.Server) bool { return formatServerType(a) < formatServerType(b) })},
+		{Key: "location", Title: "location", Less: serverLess(func(a, b *hcloud.Server) bool { return formatServerLocation(a) < formatServerLocation(b) })},
+	}
+}
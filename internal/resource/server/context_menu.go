@@ -7,6 +7,7 @@ import (
 	ctm "github.com/grammeaway/lazyhetzner/internal/context_menu"
 	"github.com/grammeaway/lazyhetzner/internal/message"
 	"github.com/grammeaway/lazyhetzner/internal/resource"
+	"github.com/grammeaway/lazyhetzner/internal/resource/actions"
 	"github.com/grammeaway/lazyhetzner/internal/resource/label"
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
 	"os"
@@ -24,13 +25,6 @@ const (
 	SessionZellij
 )
 
-// SSH Action messages
-type SshLaunchedMsg struct{}
-
-type TmuxSSHLaunchedMsg struct{}
-
-type ZellijSSHLaunchedMsg struct{}
-
 // SessionInfo holds information about the current session
 type SessionInfo struct {
 	Type        SessionType
@@ -78,6 +72,7 @@ func getServerMenuItems(sessionInfo SessionInfo) []ctm.ContextMenuItem {
 		{Label: "❌ Cancel", Action: "cancel"},
 		{Label: "🔎 View Details", Action: "view_details"},
 		{Label: "🔖 View Labels", Action: "view_labels"},
+		actions.HistoryMenuItem,
 		{Label: "📋 Copy Public IP", Action: "copy_public_ip"},
 		// ipv6
 		{Label: "📋 Copy Public IPv6", Action: "copy_public_ipv6"}, // Assuming IPv6 is also available
@@ -155,15 +150,15 @@ func launchSSH(ip string, preferredTerminal string) tea.Cmd {
 		}
 
 		if cmd == nil {
-			return message.ErrorMsg{fmt.Errorf("no suitable terminal found")}
+			return message.ErrorMsg{Err: fmt.Errorf("no suitable terminal found")}
 		}
 
 		err := cmd.Start()
 		if err != nil {
-			return message.ErrorMsg{err}
+			return message.ErrorMsg{Err: err}
 		}
 
-		return SshLaunchedMsg{}
+		return message.StatusMsg("🚀 SSH session launched")
 	}
 }
 
@@ -174,7 +169,7 @@ func launchSSHInTmuxWindow(ip string) tea.Cmd {
 		cmd := exec.Command("tmux", "new-window", "-n", windowName, fmt.Sprintf("ssh root@%s", ip))
 
 		if err := cmd.Run(); err != nil {
-			return message.ErrorMsg{fmt.Errorf("failed to create tmux window: %w", err)}
+			return message.ErrorMsg{Err: fmt.Errorf("failed to create tmux window: %w", err)}
 		}
 
 		return message.StatusMsg(fmt.Sprintf("🪟 SSH session launched in new tmux window: %s", windowName))
@@ -188,7 +183,7 @@ func launchSSHInTmuxPane(ip string) tea.Cmd {
 		cmd := exec.Command("tmux", "split-window", "-h", fmt.Sprintf("ssh root@%s", ip))
 
 		if err := cmd.Run(); err != nil {
-			return message.ErrorMsg{fmt.Errorf("failed to create tmux pane: %w", err)}
+			return message.ErrorMsg{Err: fmt.Errorf("failed to create tmux pane: %w", err)}
 		}
 
 		return message.StatusMsg("📱 SSH session launched in new tmux pane")
@@ -204,7 +199,7 @@ func launchSSHInZellijTab(ip string) tea.Cmd {
 		cmd := exec.Command("zellij", "Action", "new-tab", "--name", tabName, "--", "ssh", fmt.Sprintf("root@%s", ip))
 
 		if err := cmd.Run(); err != nil {
-			return message.ErrorMsg{fmt.Errorf("failed to create zellij tab: %w", err)}
+			return message.ErrorMsg{Err: fmt.Errorf("failed to create zellij tab: %w", err)}
 		}
 
 		return message.StatusMsg(fmt.Sprintf("🪟 SSH session launched in new zellij tab: %s", tabName))
@@ -218,7 +213,7 @@ func launchSSHInZellijPane(ip string) tea.Cmd {
 		cmd := exec.Command("zellij", "Action", "new-pane", "--", "ssh", fmt.Sprintf("root@%s", ip))
 
 		if err := cmd.Run(); err != nil {
-			return message.ErrorMsg{fmt.Errorf("failed to create zellij pane: %w", err)}
+			return message.ErrorMsg{Err: fmt.Errorf("failed to create zellij pane: %w", err)}
 		}
 
 		return message.StatusMsg("📱 SSH session launched in new zellij pane")
@@ -230,9 +225,9 @@ func launchSSHInSameTerminal(ip string) tea.Cmd {
 
 	return tea.ExecProcess(exec.Command("ssh", fmt.Sprintf("root@%s", ip)), func(err error) tea.Msg {
 		if err != nil {
-			return message.ErrorMsg{err}
+			return message.ErrorMsg{Err: err}
 		}
-		return SshLaunchedMsg{}
+		return message.StatusMsg("🚀 SSH session launched")
 	})
 }
 
@@ -257,7 +252,7 @@ func CreateServerContextMenu(server *hcloud.Server) ctm.ContextMenu {
 func handleSSHAction(Action string, server *hcloud.Server, sessionInfo SessionInfo, preferredTerminal string) tea.Cmd {
 	if server.PublicNet.IPv4.IP == nil {
 		return func() tea.Msg {
-			return message.ErrorMsg{fmt.Errorf("server has no public IP")}
+			return message.ErrorMsg{Err: fmt.Errorf("server has no public IP")}
 		}
 	}
 
@@ -312,10 +307,10 @@ func ExecuteServerContextAction(selectedAction string, server *hcloud.Server, pr
 	case "copy_public_ip":
 		return func() tea.Msg {
 			if server.PublicNet.IPv4.IP == nil {
-				return message.ErrorMsg{fmt.Errorf("server has no public IP")}
+				return message.ErrorMsg{Err: fmt.Errorf("server has no public IP")}
 			}
 			if err := clipboard.WriteAll(server.PublicNet.IPv4.IP.String()); err != nil {
-				return message.ErrorMsg{err}
+				return message.ErrorMsg{Err: err}
 			}
 			return message.ClipboardCopiedMsg(server.PublicNet.IPv4.IP.String())
 		}
@@ -323,27 +318,27 @@ func ExecuteServerContextAction(selectedAction string, server *hcloud.Server, pr
 		if server.PublicNet.IPv6.IP != nil {
 			return func() tea.Msg {
 				if err := clipboard.WriteAll(server.PublicNet.IPv6.IP.String()); err != nil {
-					return message.ErrorMsg{err}
+					return message.ErrorMsg{Err: err}
 				}
 				return message.ClipboardCopiedMsg(server.PublicNet.IPv6.IP.String())
 			}
 		}
 		return func() tea.Msg {
-			return message.ErrorMsg{fmt.Errorf("server has no public IPv6")}
+			return message.ErrorMsg{Err: fmt.Errorf("server has no public IPv6")}
 		}
 
 	case "copy_private_ip":
 		if server.PrivateNet[0].IP != nil {
 			return func() tea.Msg {
 				if err := clipboard.WriteAll(server.PrivateNet[0].IP.String()); err != nil {
-					return message.ErrorMsg{err}
+					return message.ErrorMsg{Err: err}
 				}
 
 				return message.ClipboardCopiedMsg(server.PrivateNet[0].IP.String())
 			}
 		}
 		return func() tea.Msg {
-			return message.ErrorMsg{fmt.Errorf("server has no private IP")}
+			return message.ErrorMsg{Err: fmt.Errorf("server has no private IP")}
 		}
 	default:
 		return nil
@@ -0,0 +1,216 @@
+package server
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/grammeaway/lazyhetzner/internal/registry"
+	"github.com/grammeaway/lazyhetzner/internal/resource"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+func getServerDetail(server *hcloud.Server, networks []*hcloud.Network) registry.Detail {
+	detail := getServerPreview(server)
+	// Subnets need the server's networks, which the server listing doesn't include
+	subnetLines, subnetLinks := formatServerSubnets(networks)
+	detail.Sections = slices.Insert(detail.Sections, 2, registry.DetailSection{Title: "Subnets", Lines: subnetLines, Links: subnetLinks})
+	return detail
+}
+
+// getServerPreview returns the details of a server available from the server listing
+func getServerPreview(server *hcloud.Server) registry.Detail {
+	overviewLines := []string{
+		fmt.Sprintf("Status: %s", server.Status),
+		fmt.Sprintf("Type: %s", formatServerType(server)),
+		fmt.Sprintf("Datacenter: %s", formatDatacenter(server)),
+		fmt.Sprintf("Image: %s", formatServerImage(server)),
+		fmt.Sprintf("Created: %s", resource.FormatTime(server.Created)),
+		fmt.Sprintf("Rescue Enabled: %t", server.RescueEnabled),
+	}
+	if server.PlacementGroup != nil {
+		overviewLines = append(overviewLines, fmt.Sprintf("Placement Group: %s", server.PlacementGroup.Name))
+	}
+
+	networkLines := []string{
+		fmt.Sprintf("Public IPv4: %s", resource.FormatIP(server.PublicNet.IPv4.IP)),
+		fmt.Sprintf("Public IPv6: %s", resource.FormatIP(server.PublicNet.IPv6.IP)),
+		fmt.Sprintf("Floating IPs: %s", formatFloatingIPs(server.PublicNet.FloatingIPs)),
+	}
+	networkLinks := map[int]registry.Link{}
+	privateNetLines := formatPrivateNetworks(server)
+	if len(privateNetLines) > 0 {
+		networkLines = append(networkLines, "Private Networks:")
+		for i, privateNet := range server.PrivateNet {
+			if privateNet.Network != nil {
+				networkLinks[len(networkLines)+i] = registry.Link{ResourceType: resource.ResourceNetworks, ID: privateNet.Network.ID}
+			}
+		}
+		networkLines = append(networkLines, privateNetLines...)
+	}
+	firewallLines, firewallLinks := formatServerFirewalls(server)
+	lbLines, lbLinks := formatServerLoadBalancers(server)
+	volumeLines, volumeLinks := formatServerVolumes(server)
+
+	return registry.Detail{
+		Title:  "Server Details",
+		Name:   server.Name,
+		Header: fmt.Sprintf("🖥️ Server: %s (ID: %d)", server.Name, server.ID),
+		Sections: []registry.DetailSection{
+			{Title: "Overview", Lines: overviewLines},
+			{Title: "Networking", Lines: networkLines, Links: networkLinks},
+			{Title: "Firewalls", Lines: firewallLines, Links: firewallLinks},
+			{Title: "Load Balancers", Lines: lbLines, Links: lbLinks},
+			{Title: "Volumes", Lines: volumeLines, Links: volumeLinks},
+			{Title: "Labels", Lines: resource.GetLabelLines(server.Labels)},
+		},
+		Resource: registry.Link{ResourceType: resource.ResourceServers, ID: server.ID},
+	}
+}
+
+func formatServerType(server *hcloud.Server) string {
+	if server.ServerType == nil {
+		return "n/a"
+	}
+	return server.ServerType.Name
+}
+
+func formatDatacenter(server *hcloud.Server) string {
+	if server.Datacenter == nil {
+		return "n/a"
+	}
+	return server.Datacenter.Name
+}
+
+func formatServerImage(server *hcloud.Server) string {
+	if server.Image == nil {
+		return "n/a"
+	}
+	if server.Image.Name != "" {
+		return server.Image.Name
+	}
+	return fmt.Sprintf("Image %d", server.Image.ID)
+}
+
+func formatFloatingIPs(floatingIPs []*hcloud.FloatingIP) string {
+	if len(floatingIPs) == 0 {
+		return "none"
+	}
+	parts := make([]string, 0, len(floatingIPs))
+	for _, floatingIP := range floatingIPs {
+		if floatingIP == nil {
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%s (ID: %d)", floatingIP.IP, floatingIP.ID))
+	}
+	if len(parts) == 0 {
+		return "none"
+	}
+	return strings.Join(parts, ", ")
+}
+
+func formatPrivateNetworks(server *hcloud.Server) []string {
+	if server == nil || len(server.PrivateNet) == 0 {
+		return []string{"No private networks attached."}
+	}
+	lines := make([]string, 0, len(server.PrivateNet))
+	for _, privateNet := range server.PrivateNet {
+		networkName := "unknown"
+		networkID := int64(0)
+		if privateNet.Network != nil {
+			networkName = privateNet.Network.Name
+			networkID = privateNet.Network.ID
+		}
+		aliasText := "none"
+		if len(privateNet.Aliases) > 0 {
+			aliases := make([]string, 0, len(privateNet.Aliases))
+			for _, alias := range privateNet.Aliases {
+				if alias != nil {
+					aliases = append(aliases, alias.String())
+				}
+			}
+			if len(aliases) > 0 {
+				aliasText = strings.Join(aliases, ", ")
+			}
+		}
+		lines = append(lines, fmt.Sprintf("• %s (ID: %d) | IP: %s | MAC: %s | Aliases: %s", networkName, networkID, resource.FormatIP(privateNet.IP), privateNet.MACAddress, aliasText))
+	}
+	return lines
+}
+
+// formatServerSubnets lists the subnets of the server's networks, linking each network and subnet to its view
+func formatServerSubnets(networks []*hcloud.Network) ([]string, map[int]registry.Link) {
+	if len(networks) == 0 {
+		return []string{"No subnets available."}, nil
+	}
+	lines := []string{}
+	links := map[int]registry.Link{}
+	for _, network := range networks {
+		if network == nil {
+			continue
+		}
+		links[len(lines)] = registry.Link{ResourceType: resource.ResourceNetworks, ID: network.ID}
+		lines = append(lines, fmt.Sprintf("%s (ID: %d)", network.Name, network.ID))
+		if len(network.Subnets) == 0 {
+			lines = append(lines, "  • No subnets defined.")
+			continue
+		}
+		for _, subnet := range network.Subnets {
+			ipRange := "n/a"
+			if subnet.IPRange != nil {
+				ipRange = subnet.IPRange.String()
+				links[len(lines)] = registry.Link{ResourceType: resource.ResourceNetworks, ID: network.ID, Part: resource.SubnetPart(ipRange)}
+			}
+			lines = append(lines, fmt.Sprintf("  • %s (%s) | Zone: %s | Gateway: %s", ipRange, strings.ToUpper(string(subnet.Type)), subnet.NetworkZone, resource.FormatIP(subnet.Gateway)))
+		}
+	}
+	return lines, links
+}
+
+// formatServerFirewalls lists the firewalls applied to the server, linking each to its view
+func formatServerFirewalls(server *hcloud.Server) ([]string, map[int]registry.Link) {
+	if server == nil || len(server.PublicNet.Firewalls) == 0 {
+		return []string{"No firewalls attached."}, nil
+	}
+	lines := make([]string, 0, len(server.PublicNet.Firewalls))
+	links := map[int]registry.Link{}
+	for _, firewallStatus := range server.PublicNet.Firewalls {
+		links[len(lines)] = registry.Link{ResourceType: resource.ResourceFirewalls, ID: firewallStatus.Firewall.ID}
+		lines = append(lines, fmt.Sprintf("• %s (ID: %d) | Status: %s", firewallStatus.Firewall.Name, firewallStatus.Firewall.ID, firewallStatus.Status))
+	}
+	return lines, links
+}
+
+// formatServerLoadBalancers lists the load balancers targeting the server, linking each to its view
+func formatServerLoadBalancers(server *hcloud.Server) ([]string, map[int]registry.Link) {
+	if server == nil || len(server.LoadBalancers) == 0 {
+		return []string{"No load balancers attached."}, nil
+	}
+	lines := make([]string, 0, len(server.LoadBalancers))
+	links := map[int]registry.Link{}
+	for _, lb := range server.LoadBalancers {
+		if lb == nil {
+			continue
+		}
+		links[len(lines)] = registry.Link{ResourceType: resource.ResourceLoadBalancers, ID: lb.ID}
+		lines = append(lines, fmt.Sprintf("• %s (ID: %d)", lb.Name, lb.ID))
+	}
+	return lines, links
+}
+
+// formatServerVolumes lists the volumes attached to the server, linking each to its view
+func formatServerVolumes(server *hcloud.Server) ([]string, map[int]registry.Link) {
+	if server == nil || len(server.Volumes) == 0 {
+		return []string{"No volumes attached."}, nil
+	}
+	lines := make([]string, 0, len(server.Volumes))
+	links := map[int]registry.Link{}
+	for _, volume := range server.Volumes {
+		if volume == nil {
+			continue
+		}
+		links[len(lines)] = registry.Link{ResourceType: resource.ResourceVolumes, ID: volume.ID}
+		lines = append(lines, fmt.Sprintf("• %s (ID: %d) | Size: %d GB", volume.Name, volume.ID, volume.Size))
+	}
+	return lines, links
+}
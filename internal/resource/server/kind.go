@@ -0,0 +1,109 @@
+package server
+
+import (
+	"context"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	ctm "github.com/grammeaway/lazyhetzner/internal/context_menu"
+	"github.com/grammeaway/lazyhetzner/internal/registry"
+	"github.com/grammeaway/lazyhetzner/internal/resource"
+	"github.com/grammeaway/lazyhetzner/internal/resource/actions"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+type kind struct{}
+
+func init() {
+	registry.Register(kind{})
+}
+
+func (kind) Type() resource.ResourceType { return resource.ResourceServers }
+func (kind) Name() string                { return "Servers" }
+func (kind) Key() string                 { return "servers" }
+
+func (kind) Load(ctx context.Context, client *hcloud.Client, labelSelector string, serverIndex *resource.ServerIndexStore) tea.Cmd {
+	return LoadServers(ctx, client, labelSelector, serverIndex)
+}
+
+func (kind) Items(msg tea.Msg) ([]list.Item, bool) {
+	loaded, ok := msg.(ServersLoadedMsg)
+	if !ok {
+		return nil, false
+	}
+	items := make([]list.Item, len(loaded.Servers))
+	for i, server := range loaded.Servers {
+		items[i] = ServerItem{
+			Server:       server,
+			ResourceType: resource.ResourceServers,
+			ResourceID:   server.ID,
+		}
+	}
+	return items, true
+}
+
+func (kind) ItemID(item list.Item) (int64, bool) {
+	if serverItem, ok := item.(ServerItem); ok {
+		return serverItem.Server.ID, true
+	}
+	return 0, false
+}
+
+func (kind) Labels(item list.Item) map[string]string {
+	if serverItem, ok := item.(ServerItem); ok {
+		return getServerLabels(serverItem.Server)
+	}
+	return nil
+}
+
+func (kind) Fingerprint(item list.Item) string {
+	serverItem, ok := item.(ServerItem)
+	if !ok {
+		return ""
+	}
+	server := serverItem.Server
+	parts := []string{string(server.Status), server.PublicNet.IPv4.IP.String()}
+	if server.PublicNet.IPv6.Network != nil {
+		parts = append(parts, server.PublicNet.IPv6.Network.String())
+	}
+	for _, privateNet := range server.PrivateNet {
+		parts = append(parts, privateNet.IP.String())
+	}
+	return strings.Join(parts, "|")
+}
+
+func (kind) ContextMenu(item list.Item) (ctm.ContextMenu, bool) {
+	if serverItem, ok := item.(ServerItem); ok {
+		return CreateServerContextMenu(serverItem.Server), true
+	}
+	return ctm.ContextMenu{}, false
+}
+
+func (kind) ExecuteAction(ctx context.Context, action string, item list.Item, env registry.ActionEnv) tea.Cmd {
+	serverItem, ok := item.(ServerItem)
+	if !ok {
+		return nil
+	}
+	if action == "view_details" {
+		return LoadServerDetails(ctx, env.Client, serverItem.Server.ID)
+	}
+	if action == actions.HistoryMenuItem.Action {
+		return actions.LoadResourceActions(ctx, env.Client, resource.ResourceServers, serverItem.Server.ID, serverItem.Server.Name)
+	}
+	return ExecuteServerContextAction(action, serverItem.Server, env.DefaultTerminal)
+}
+
+func (kind) Detail(msg tea.Msg) (registry.Detail, bool) {
+	if loaded, ok := msg.(ServerDetailsLoadedMsg); ok && loaded.Server != nil {
+		return getServerDetail(loaded.Server, loaded.Networks), true
+	}
+	return registry.Detail{}, false
+}
+
+func (kind) Preview(item list.Item) (registry.Detail, bool) {
+	if serverItem, ok := item.(ServerItem); ok {
+		return getServerPreview(serverItem.Server), true
+	}
+	return registry.Detail{}, false
+}
@@ -0,0 +1,62 @@
+package server
+
+import (
+	"context"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/grammeaway/lazyhetzner/internal/metrics"
+	"github.com/grammeaway/lazyhetzner/internal/resource"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+// serverSeries are the time series charted for servers, in the order they are shown
+var serverSeries = []struct {
+	key   string
+	title string
+	unit  metrics.Unit
+}{
+	{"cpu", "CPU", metrics.UnitPercent},
+	{"disk.0.iops.read", "Disk read IOPS", metrics.UnitPerSecond},
+	{"disk.0.iops.write", "Disk write IOPS", metrics.UnitPerSecond},
+	{"disk.0.bandwidth.read", "Disk read", metrics.UnitBytesPerSecond},
+	{"disk.0.bandwidth.write", "Disk write", metrics.UnitBytesPerSecond},
+	{"network.0.pps.in", "Packets in", metrics.UnitPerSecond},
+	{"network.0.pps.out", "Packets out", metrics.UnitPerSecond},
+	{"network.0.bandwidth.in", "Network in", metrics.UnitBytesPerSecond},
+	{"network.0.bandwidth.out", "Network out", metrics.UnitBytesPerSecond},
+}
+
+func (kind) LoadMetrics(ctx context.Context, client *hcloud.Client, item list.Item, window metrics.Window) tea.Cmd {
+	serverItem, ok := item.(ServerItem)
+	if !ok {
+		return nil
+	}
+	server := serverItem.Server
+	return func() tea.Msg {
+		loaded := metrics.LoadedMsg{ResourceType: resource.ResourceServers, ID: server.ID, Window: window}
+		start, end := window.Range()
+		serverMetrics, _, err := client.Server.GetMetrics(ctx, server, hcloud.ServerGetMetricsOpts{
+			Types: []hcloud.ServerMetricType{hcloud.ServerMetricCPU, hcloud.ServerMetricDisk, hcloud.ServerMetricNetwork},
+			Start: start,
+			End:   end,
+			Step:  window.Step(),
+		})
+		if err != nil {
+			loaded.Err = err
+			return loaded
+		}
+		for _, series := range serverSeries {
+			values, exists := serverMetrics.TimeSeries[series.key]
+			if !exists {
+				continue
+			}
+			parsed := make([]float64, len(values))
+			for i, value := range values {
+				parsed[i] = metrics.ParseValue(value.Value)
+			}
+			loaded.Series = append(loaded.Series, metrics.Series{Title: series.title, Unit: series.unit, Values: parsed})
+		}
+		return loaded
+	}
+}
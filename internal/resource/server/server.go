@@ -3,6 +3,7 @@ package server
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/grammeaway/lazyhetzner/internal/message"
@@ -47,26 +48,56 @@ func (i ServerItem) Description() string {
 	return fmt.Sprintf("%s | %s | %s | %s", statusDisplay, i.Server.ServerType.Name, publicIP, privateIP)
 }
 
-func LoadServers(client *hcloud.Client) tea.Cmd {
+// SearchTerms returns the strings the global search matches a server against
+func (i ServerItem) SearchTerms() []string {
+	terms := []string{i.Server.Name, strconv.FormatInt(i.Server.ID, 10)}
+	if i.Server.PublicNet.IPv4.IP != nil {
+		terms = append(terms, i.Server.PublicNet.IPv4.IP.String())
+	}
+	if i.Server.PublicNet.IPv6.Network != nil {
+		terms = append(terms, i.Server.PublicNet.IPv6.Network.String())
+	}
+	for _, privateNet := range i.Server.PrivateNet {
+		if privateNet.IP != nil {
+			terms = append(terms, privateNet.IP.String())
+		}
+		for _, alias := range privateNet.Aliases {
+			terms = append(terms, alias.String())
+		}
+	}
+	return append(terms, resource.GetLabelTerms(i.Server.Labels)...)
+}
+
+// LoadServers loads the servers tab. Unfiltered listings go through the server index store,
+// so they also serve the attached server lookups of volumes and floating IPs.
+func LoadServers(ctx context.Context, client *hcloud.Client, labelSelector string, serverIndex *resource.ServerIndexStore) tea.Cmd {
 	return func() tea.Msg {
-		ctx := context.Background()
-		servers, err := client.Server.All(ctx)
+		var (
+			servers []*hcloud.Server
+			err     error
+		)
+		if labelSelector == "" {
+			servers, err = serverIndex.Refresh(ctx, client)
+		} else {
+			servers, err = client.Server.AllWithOpts(ctx, hcloud.ServerListOpts{
+				ListOpts: hcloud.ListOpts{LabelSelector: labelSelector},
+			})
+		}
 		if err != nil {
-			return message.ErrorMsg{err}
+			return message.ErrorMsg{Err: err}
 		}
 		return ServersLoadedMsg{Servers: servers}
 	}
 }
 
-func LoadServerDetails(client *hcloud.Client, serverID int64) tea.Cmd {
+func LoadServerDetails(ctx context.Context, client *hcloud.Client, serverID int64) tea.Cmd {
 	return func() tea.Msg {
-		ctx := context.Background()
 		server, _, err := client.Server.GetByID(ctx, serverID)
 		if err != nil {
-			return message.ErrorMsg{err}
+			return message.ErrorMsg{Err: err}
 		}
 		if server == nil {
-			return message.ErrorMsg{fmt.Errorf("server with ID %d not found", serverID)}
+			return message.ErrorMsg{Err: fmt.Errorf("server with ID %d not found", serverID)}
 		}
 
 		networks := make([]*hcloud.Network, 0, len(server.PrivateNet))
@@ -76,7 +107,7 @@ func LoadServerDetails(client *hcloud.Client, serverID int64) tea.Cmd {
 			}
 			network, _, err := client.Network.GetByID(ctx, privateNet.Network.ID)
 			if err != nil {
-				return message.ErrorMsg{err}
+				return message.ErrorMsg{Err: err}
 			}
 			if network != nil {
 				networks = append(networks, network)
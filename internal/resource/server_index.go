@@ -0,0 +1,96 @@
+package resource
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/grammeaway/lazyhetzner/internal/cache"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+// How long a server listing is reused to resolve attached servers before it is fetched again
+const serverIndexMaxAge = 30 * time.Second
+
+// ServerIndex maps server IDs to the servers of a single Server.All call
+type ServerIndex map[int64]*hcloud.Server
+
+func NewServerIndex(servers []*hcloud.Server) ServerIndex {
+	index := make(ServerIndex, len(servers))
+	for _, server := range servers {
+		index[server.ID] = server
+	}
+	return index
+}
+
+// Resolve returns the full server for a server reference, or the reference itself if it is unknown
+func (index ServerIndex) Resolve(server *hcloud.Server) *hcloud.Server {
+	if server == nil {
+		return nil
+	}
+	if resolved, ok := index[server.ID]; ok {
+		return resolved
+	}
+	return server
+}
+
+// ServerIndexStore shares server listings between the loaders of a project, so attached servers of
+// volumes and floating IPs are resolved with one Server.All call instead of one lookup per resource.
+// Concurrent requests for the same client wait for a single listing.
+type ServerIndexStore struct {
+	mu      sync.Mutex
+	locks   map[*hcloud.Client]*sync.Mutex
+	entries *cache.Store[*hcloud.Client, []*hcloud.Server]
+}
+
+func NewServerIndexStore() *ServerIndexStore {
+	return &ServerIndexStore{
+		locks:   make(map[*hcloud.Client]*sync.Mutex),
+		entries: cache.NewStore[*hcloud.Client, []*hcloud.Server](),
+	}
+}
+
+// Get returns a server index for the client, reusing a recent listing
+func (s *ServerIndexStore) Get(ctx context.Context, client *hcloud.Client) (ServerIndex, error) {
+	servers, err := s.list(ctx, client, time.Now().Add(-serverIndexMaxAge))
+	if err != nil {
+		return nil, err
+	}
+	return NewServerIndex(servers), nil
+}
+
+// Refresh lists all servers of the client, unless another listing completed while waiting for it
+func (s *ServerIndexStore) Refresh(ctx context.Context, client *hcloud.Client) ([]*hcloud.Server, error) {
+	return s.list(ctx, client, time.Now())
+}
+
+func (s *ServerIndexStore) Clear() {
+	s.entries.Clear()
+}
+
+func (s *ServerIndexStore) list(ctx context.Context, client *hcloud.Client, notBefore time.Time) ([]*hcloud.Server, error) {
+	lock := s.getLock(client)
+	lock.Lock()
+	defer lock.Unlock()
+
+	if entry, ok := s.entries.Get(client); ok && !entry.LoadedAt.Before(notBefore) {
+		return entry.Value, nil
+	}
+	servers, err := client.Server.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.entries.Set(client, servers)
+	return servers, nil
+}
+
+func (s *ServerIndexStore) getLock(client *hcloud.Client) *sync.Mutex {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	lock, exists := s.locks[client]
+	if !exists {
+		lock = &sync.Mutex{}
+		s.locks[client] = lock
+	}
+	return lock
+}
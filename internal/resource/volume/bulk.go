@@ -0,0 +1,40 @@
+package volume
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/grammeaway/lazyhetzner/internal/registry"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+func (kind) ItemName(item list.Item) string {
+	if i, ok := item.(VolumeItem); ok {
+		return i.Volume.Name
+	}
+	return ""
+}
+
+func (kind) GetLabels(ctx context.Context, client *hcloud.Client, id int64) (map[string]string, error) {
+	volume, _, err := client.Volume.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if volume == nil {
+		return nil, fmt.Errorf("volume %d not found", id)
+	}
+	return volume.Labels, nil
+}
+
+func (kind) UpdateLabels(ctx context.Context, client *hcloud.Client, id int64, labels map[string]string) error {
+	_, _, err := client.Volume.Update(ctx, &hcloud.Volume{ID: id}, hcloud.VolumeUpdateOpts{Labels: labels})
+	return err
+}
+
+func (kind) Delete(ctx context.Context, client *hcloud.Client, id int64) error {
+	_, err := client.Volume.Delete(ctx, &hcloud.Volume{ID: id})
+	return err
+}
+
+func (kind) BulkActions() []registry.BulkAction { return nil }
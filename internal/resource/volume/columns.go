@@ -0,0 +1,68 @@
+package volume
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/grammeaway/lazyhetzner/internal/registry"
+	"github.com/grammeaway/lazyhetzner/internal/resource"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+func (kind) Columns() []registry.Column {
+	return []registry.Column{
+		{Key: "name", Title: "Name", Width: 24, Value: volumeValue(func(v *hcloud.Volume) string { return v.Name })},
+		{
+			Key: "size", Title: "Size", Width: 8,
+			Value: volumeValue(func(v *hcloud.Volume) string { return fmt.Sprintf("%d GB", v.Size) }),
+			Less:  volumeLess(func(a, b *hcloud.Volume) bool { return a.Size < b.Size }),
+		},
+		{
+			Key: "server", Title: "Server", Width: 20,
+			Value: volumeValue(func(v *hcloud.Volume) string {
+				if v.Server == nil {
+					return "unattached"
+				}
+				if v.Server.Name == "" {
+					return fmt.Sprintf("Server %d", v.Server.ID)
+				}
+				return v.Server.Name
+			}),
+		},
+		{
+			Key: "location", Title: "Location", Width: 8,
+			Value: volumeValue(func(v *hcloud.Volume) string {
+				if v.Location == nil {
+					return "n/a"
+				}
+				return v.Location.Name
+			}),
+		},
+		{Key: "labels", Title: "Labels", Width: 30, Value: volumeValue(func(v *hcloud.Volume) string { return resource.FormatLabels(v.Labels) })},
+	}
+}
+
+func volumeValue(value func(*hcloud.Volume) string) func(list.Item) string {
+	return func(item list.Item) string {
+		if volumeItem, ok := item.(VolumeItem); ok {
+			return value(volumeItem.Volume)
+		}
+		return ""
+	}
+}
+
+func volumeLess(less func(a, b *hcloud.Volume) bool) func(a, b list.Item) bool {
+	return func(a, b list.Item) bool {
+		volumeA, okA := a.(VolumeItem)
+		volumeB, okB := b.(VolumeItem)
+		return okA && okB && less(volumeA.Volume, volumeB.Volume)
+	}
+}
+
+func (kind) SortKeys() []registry.SortKey {
+	return []registry.SortKey{
+		{Key: "name", Title: "name", Less: volumeLess(func(a, b *hcloud.Volume) bool { return strings.ToLower(a.Name) < strings.ToLower(b.Name) })},
+		{Key: "size", Title: "size", Less: volumeLess(func(a, b *hcloud.Volume) bool { return a.Size < b.Size })},
+	}
+}
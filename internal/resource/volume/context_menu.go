@@ -7,6 +7,7 @@ import (
 	ctm "github.com/grammeaway/lazyhetzner/internal/context_menu"
 	"github.com/grammeaway/lazyhetzner/internal/message"
 	"github.com/grammeaway/lazyhetzner/internal/resource"
+	"github.com/grammeaway/lazyhetzner/internal/resource/actions"
 	"github.com/grammeaway/lazyhetzner/internal/resource/label"
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
 )
@@ -34,6 +35,7 @@ func getVolumeMenuItems() []ctm.ContextMenuItem {
 		// add action for canceling (i.e., closing) the context menu
 		{Label: "❌ Cancel", Action: "cancel"},
 		{Label: "🔖 View Labels", Action: "view_labels"},
+		actions.HistoryMenuItem,
 		// Copy the volume ID to clipboard
 		{Label: "📋 Copy Volume ID", Action: "copy_id"},
 		// Copy the volume name to clipboard
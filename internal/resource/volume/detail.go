@@ -0,0 +1,59 @@
+package volume
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/grammeaway/lazyhetzner/internal/registry"
+	"github.com/grammeaway/lazyhetzner/internal/resource"
+)
+
+func (kind) Preview(item list.Item) (registry.Detail, bool) {
+	volumeItem, ok := item.(VolumeItem)
+	if !ok {
+		return registry.Detail{}, false
+	}
+	volume := volumeItem.Volume
+	location, format := "n/a", "unformatted"
+	if volume.Location != nil {
+		location = volume.Location.Name
+	}
+	if volume.Format != nil && *volume.Format != "" {
+		format = *volume.Format
+	}
+
+	attachmentLines := []string{"Not attached to a server."}
+	attachmentLinks := map[int]registry.Link{}
+	if volume.Server != nil {
+		attachmentLinks[0] = registry.Link{ResourceType: resource.ResourceServers, ID: volume.Server.ID}
+		server := volume.Server.Name
+		if server == "" {
+			server = fmt.Sprintf("%d", volume.Server.ID)
+		}
+		attachmentLines = []string{
+			fmt.Sprintf("Server: %s", server),
+			fmt.Sprintf("Linux Device: %s", volume.LinuxDevice),
+		}
+	}
+
+	return registry.Detail{
+		Title:  "Volume Details",
+		Name:   volume.Name,
+		Header: fmt.Sprintf("💾 Volume: %s (ID: %d)", volume.Name, volume.ID),
+		Sections: []registry.DetailSection{
+			{
+				Title: "Overview",
+				Lines: []string{
+					fmt.Sprintf("Size: %d GB", volume.Size),
+					fmt.Sprintf("Status: %s", volume.Status),
+					fmt.Sprintf("Location: %s", location),
+					fmt.Sprintf("Format: %s", format),
+					fmt.Sprintf("Created: %s", resource.FormatTime(volume.Created)),
+					fmt.Sprintf("Delete Protection: %t", volume.Protection.Delete),
+				},
+			},
+			{Title: "Attachment", Lines: attachmentLines, Links: attachmentLinks},
+			{Title: "Labels", Lines: resource.GetLabelLines(volume.Labels)},
+		},
+	}, true
+}
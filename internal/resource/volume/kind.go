@@ -0,0 +1,93 @@
+package volume
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	ctm "github.com/grammeaway/lazyhetzner/internal/context_menu"
+	"github.com/grammeaway/lazyhetzner/internal/registry"
+	"github.com/grammeaway/lazyhetzner/internal/resource"
+	"github.com/grammeaway/lazyhetzner/internal/resource/actions"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+type kind struct{}
+
+func init() {
+	registry.Register(kind{})
+}
+
+func (kind) Type() resource.ResourceType { return resource.ResourceVolumes }
+func (kind) Name() string                { return "Volumes" }
+func (kind) Key() string                 { return "volumes" }
+
+func (kind) Load(ctx context.Context, client *hcloud.Client, labelSelector string, serverIndex *resource.ServerIndexStore) tea.Cmd {
+	return LoadVolumes(ctx, client, labelSelector, serverIndex)
+}
+
+func (kind) Items(msg tea.Msg) ([]list.Item, bool) {
+	loaded, ok := msg.(VolumesLoadedMsg)
+	if !ok {
+		return nil, false
+	}
+	items := make([]list.Item, len(loaded.Volumes))
+	for i, volume := range loaded.Volumes {
+		items[i] = VolumeItem{
+			Volume:       volume,
+			ResourceType: resource.ResourceVolumes,
+			ResourceID:   volume.ID,
+		}
+	}
+	return items, true
+}
+
+func (kind) ItemID(item list.Item) (int64, bool) {
+	if volumeItem, ok := item.(VolumeItem); ok {
+		return volumeItem.Volume.ID, true
+	}
+	return 0, false
+}
+
+func (kind) Labels(item list.Item) map[string]string {
+	if volumeItem, ok := item.(VolumeItem); ok {
+		return getVolumeLabels(volumeItem.Volume)
+	}
+	return nil
+}
+
+func (kind) Fingerprint(item list.Item) string {
+	volumeItem, ok := item.(VolumeItem)
+	if !ok {
+		return ""
+	}
+	parts := []string{string(volumeItem.Volume.Status), fmt.Sprintf("size=%d", volumeItem.Volume.Size)}
+	if volumeItem.Volume.Server != nil {
+		parts = append(parts, fmt.Sprintf("server=%d", volumeItem.Volume.Server.ID))
+	}
+	return strings.Join(parts, "|")
+}
+
+func (kind) ContextMenu(item list.Item) (ctm.ContextMenu, bool) {
+	if volumeItem, ok := item.(VolumeItem); ok {
+		return CreateVolumeContextMenu(volumeItem.Volume), true
+	}
+	return ctm.ContextMenu{}, false
+}
+
+func (kind) ExecuteAction(ctx context.Context, action string, item list.Item, env registry.ActionEnv) tea.Cmd {
+	if volumeItem, ok := item.(VolumeItem); ok {
+		if action == actions.HistoryMenuItem.Action {
+			return actions.LoadResourceActions(ctx, env.Client, resource.ResourceVolumes, volumeItem.Volume.ID, volumeItem.Volume.Name)
+		}
+		return ExecuteVolumeContextAction(action, volumeItem.Volume)
+	}
+	return nil
+}
+
+// Volumes have no detail view
+func (kind) Detail(tea.Msg) (registry.Detail, bool) {
+	return registry.Detail{}, false
+}
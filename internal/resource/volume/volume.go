@@ -3,9 +3,11 @@ package volume
 import (
 	"context"
 	"fmt"
+	"strconv"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/hetznercloud/hcloud-go/v2/hcloud"
 	"github.com/grammeaway/lazyhetzner/internal/message"
+	"github.com/grammeaway/lazyhetzner/internal/resource"
 )
 
 type VolumesLoadedMsg struct {
@@ -14,6 +16,9 @@ type VolumesLoadedMsg struct {
 
 type VolumeItem struct {
 	Volume *hcloud.Volume
+
+	ResourceType resource.ResourceType
+	ResourceID   int64
 }
 
 func (i VolumeItem) FilterValue() string { return i.Volume.Name }
@@ -26,23 +31,45 @@ func (i VolumeItem) Description() string {
 	return fmt.Sprintf("%s | %dGB | %s", status, i.Volume.Size, i.Volume.Location.Name)
 }
 
-func LoadVolumes(client *hcloud.Client) tea.Cmd {
+// SearchTerms returns the strings the global search matches a volume against
+func (i VolumeItem) SearchTerms() []string {
+	terms := []string{i.Volume.Name, strconv.FormatInt(i.Volume.ID, 10)}
+	if i.Volume.LinuxDevice != "" {
+		terms = append(terms, i.Volume.LinuxDevice)
+	}
+	if i.Volume.Server != nil && i.Volume.Server.Name != "" {
+		terms = append(terms, i.Volume.Server.Name)
+	}
+	return append(terms, resource.GetLabelTerms(i.Volume.Labels)...)
+}
+
+func LoadVolumes(ctx context.Context, client *hcloud.Client, labelSelector string, serverIndex *resource.ServerIndexStore) tea.Cmd {
 	return func() tea.Msg {
-		ctx := context.Background()
-		volumes, err := client.Volume.All(ctx)
+		volumes, err := client.Volume.AllWithOpts(ctx, hcloud.VolumeListOpts{
+			ListOpts: hcloud.ListOpts{LabelSelector: labelSelector},
+		})
 		if err != nil {
-			return message.ErrorMsg{err}
+			return message.ErrorMsg{Err: err}
 		}
 
-		// Populate Server name fields for attached servers
-		for _, vol := range volumes {
-			if vol.Server != nil {
-				server, _, err := client.Server.GetByID(ctx, vol.Server.ID)
-				if err == nil && server != nil {
-					vol.Server = server
+		// Populate Server name fields for attached servers from a single server listing
+		if hasAttachedServer(volumes) {
+			servers, err := serverIndex.Get(ctx, client)
+			if err == nil {
+				for _, vol := range volumes {
+					vol.Server = servers.Resolve(vol.Server)
 				}
 			}
 		}
 		return VolumesLoadedMsg{Volumes: volumes}
 	}
 }
+
+func hasAttachedServer(volumes []*hcloud.Volume) bool {
+	for _, vol := range volumes {
+		if vol.Server != nil {
+			return true
+		}
+	}
+	return false
+}
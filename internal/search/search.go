@@ -0,0 +1,93 @@
+package search
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/grammeaway/lazyhetzner/internal/resource"
+	"github.com/sahilm/fuzzy"
+)
+
+// Scores used to rank literal matches above fuzzy ones
+const (
+	exactMatchScore     = 3000
+	prefixMatchScore    = 2000
+	substringMatchScore = 1000
+)
+
+// Entry is a single searchable resource. Project is set when searching across projects.
+type Entry struct {
+	ResourceType resource.ResourceType
+	ID           int64
+	Name         string
+	Terms        []string
+	Project      string
+}
+
+// Match is an entry that matched the query, along with the term that matched best
+type Match struct {
+	Entry       Entry
+	MatchedTerm string
+	Score       int
+}
+
+// Search matches the query against all entries and returns the matches grouped by
+// resource type, best matches first within each group
+func Search(query string, entries []Entry) []Match {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return []Match{}
+	}
+
+	matches := []Match{}
+	for _, entry := range entries {
+		if match, ok := matchEntry(query, entry); ok {
+			matches = append(matches, match)
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Entry.ResourceType != matches[j].Entry.ResourceType {
+			return matches[i].Entry.ResourceType < matches[j].Entry.ResourceType
+		}
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].Entry.Name < matches[j].Entry.Name
+	})
+	return matches
+}
+
+func matchEntry(query string, entry Entry) (Match, bool) {
+	lowerQuery := strings.ToLower(query)
+	best := Match{Entry: entry, Score: -1}
+
+	for _, term := range entry.Terms {
+		lowerTerm := strings.ToLower(term)
+		score := -1
+		switch {
+		case lowerTerm == lowerQuery:
+			score = exactMatchScore
+		case strings.HasPrefix(lowerTerm, lowerQuery):
+			score = prefixMatchScore
+		case strings.Contains(lowerTerm, lowerQuery):
+			score = substringMatchScore
+		}
+		if score > best.Score {
+			best.Score = score
+			best.MatchedTerm = term
+		}
+	}
+	if best.Score >= 0 {
+		return best, true
+	}
+
+	// Fall back to fuzzy matching, which always ranks below literal matches
+	fuzzyMatches := fuzzy.Find(query, entry.Terms)
+	if len(fuzzyMatches) == 0 {
+		return Match{}, false
+	}
+	best.MatchedTerm = fuzzyMatches[0].Str
+	best.Score = min(fuzzyMatches[0].Score, substringMatchScore-1)
+	return best, true
+}
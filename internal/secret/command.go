@@ -0,0 +1,81 @@
+package secret
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// ProjectPlaceholder is replaced by the quoted project name in the templates of a command store
+const ProjectPlaceholder = "{project}"
+
+// Command reads tokens from the first line printed by their reference, run through the shell, like
+// "pass show hcloud/prod" or "op read op://Private/hcloud-prod/token". Commands don't get a terminal,
+// so they need to be able to ask for unlocking on their own, like gpg-agent or the 1Password app do.
+type Command struct {
+	// StoreTemplate keeps a token given on its standard input, like "pass insert -m -f hcloud/{project}"
+	StoreTemplate string
+	// GetTemplate reads back tokens kept with StoreTemplate, like "pass show hcloud/{project}"
+	GetTemplate string
+}
+
+// Get runs the command of a reference and returns the first line of its output
+func (c Command) Get(ctx context.Context, ref string) (string, error) {
+	output, err := runShell(ctx, ref, nil)
+	if err != nil {
+		return "", err
+	}
+	token, _, _ := strings.Cut(string(output), "\n")
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "", fmt.Errorf("%q printed no token", ref)
+	}
+	return token, nil
+}
+
+// Set runs the store template of a project with the token on its standard input
+func (c Command) Set(ctx context.Context, project, token string) (string, error) {
+	if c.StoreTemplate == "" || c.GetTemplate == "" {
+		return "", errors.New("storing tokens with a command needs both a store and a get command")
+	}
+	if _, err := runShell(ctx, expandTemplate(c.StoreTemplate, project), strings.NewReader(token+"\n")); err != nil {
+		return "", err
+	}
+	return expandTemplate(c.GetTemplate, project), nil
+}
+
+func expandTemplate(template, project string) string {
+	return strings.ReplaceAll(template, ProjectPlaceholder, quoteShell(project))
+}
+
+func quoteShell(s string) string {
+	if runtime.GOOS == "windows" {
+		return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func runShell(ctx context.Context, command string, stdin io.Reader) ([]byte, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	cmd.Stdin = stdin
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return nil, fmt.Errorf("running %q failed: %w: %s", command, err, message)
+		}
+		return nil, fmt.Errorf("running %q failed: %w", command, err)
+	}
+	return output, nil
+}
@@ -0,0 +1,18 @@
+// Package secret reads and keeps the API tokens of projects outside of config.json: in a vault file
+// encrypted with a passphrase, or behind an external command like a password manager.
+package secret
+
+import "context"
+
+// Backend reads the tokens its references point to
+type Backend interface {
+	// Get returns the token a reference points to
+	Get(ctx context.Context, ref string) (string, error)
+}
+
+// Store is a backend tokens can be moved into
+type Store interface {
+	Backend
+	// Set keeps the token of a project, returning the reference to read it back with
+	Set(ctx context.Context, project, token string) (string, error)
+}
@@ -0,0 +1,191 @@
+package secret
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const (
+	vaultVersion = 1
+	vaultKDF     = "pbkdf2-sha256"
+	// Iterations used for new vaults, as recommended by OWASP for PBKDF2-HMAC-SHA256
+	vaultIterations = 600000
+	saltSize        = 16
+	keySize         = 32
+)
+
+// ErrWrongPassphrase is returned when a vault can't be decrypted, which is almost always a mistyped passphrase
+var ErrWrongPassphrase = errors.New("wrong vault passphrase, or the vault is damaged")
+
+// vaultFile is the format of a vault on disk. Byte slices are base64 encoded by encoding/json.
+type vaultFile struct {
+	Version    int    `json:"version"`
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	// Data holds the tokens keyed by project name, as JSON encrypted with AES-256-GCM
+	Data []byte `json:"data"`
+}
+
+// Vault keeps tokens in a file encrypted with a key derived from a passphrase. The key is kept in memory
+// once unlocked, so tokens can be added without asking for the passphrase again.
+type Vault struct {
+	mu         sync.Mutex
+	path       string
+	salt       []byte
+	iterations int
+	key        []byte
+	tokens     map[string]string
+}
+
+// VaultExists reports whether there is a vault at path
+func VaultExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// OpenVault unlocks the vault at path, or starts an empty one if there is no vault yet
+func OpenVault(path, passphrase string) (*Vault, error) {
+	if passphrase == "" {
+		return nil, errors.New("the vault passphrase is empty")
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		salt := make([]byte, saltSize)
+		rand.Read(salt)
+		key, err := deriveKey(passphrase, salt, vaultIterations)
+		if err != nil {
+			return nil, err
+		}
+		return &Vault{path: path, salt: salt, iterations: vaultIterations, key: key, tokens: make(map[string]string)}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var file vaultFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("reading the vault %s: %w", path, err)
+	}
+	if file.Version != vaultVersion || file.KDF != vaultKDF {
+		return nil, fmt.Errorf("the vault %s has an unsupported format (version %d, %s)", path, file.Version, file.KDF)
+	}
+	key, err := deriveKey(passphrase, file.Salt, file.Iterations)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := gcm.Open(nil, file.Nonce, file.Data, nil)
+	if err != nil {
+		return nil, ErrWrongPassphrase
+	}
+	tokens := make(map[string]string)
+	if err := json.Unmarshal(plaintext, &tokens); err != nil {
+		return nil, fmt.Errorf("reading the vault %s: %w", path, err)
+	}
+	return &Vault{path: path, salt: file.Salt, iterations: file.Iterations, key: key, tokens: tokens}, nil
+}
+
+func deriveKey(passphrase string, salt []byte, iterations int) ([]byte, error) {
+	return pbkdf2.Key(sha256.New, passphrase, salt, iterations, keySize)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Path returns the location of the vault
+func (v *Vault) Path() string {
+	return v.path
+}
+
+// Get returns the token kept under a reference, which is the name of the project it was stored for
+func (v *Vault) Get(_ context.Context, ref string) (string, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	token, exists := v.tokens[ref]
+	if !exists {
+		return "", fmt.Errorf("the vault has no token for %q", ref)
+	}
+	return token, nil
+}
+
+// Set keeps the token of a project in the vault and writes it to disk
+func (v *Vault) Set(_ context.Context, project, token string) (string, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.tokens[project] = token
+	if err := v.save(); err != nil {
+		return "", err
+	}
+	return project, nil
+}
+
+// Delete removes a token from the vault and writes it to disk
+func (v *Vault) Delete(ref string) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if _, exists := v.tokens[ref]; !exists {
+		return nil
+	}
+	delete(v.tokens, ref)
+	return v.save()
+}
+
+// save encrypts the tokens with a new nonce and replaces the vault file, so a failed write never damages it
+func (v *Vault) save() error {
+	plaintext, err := json.Marshal(v.tokens)
+	if err != nil {
+		return err
+	}
+	gcm, err := newGCM(v.key)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	rand.Read(nonce)
+	data, err := json.MarshalIndent(vaultFile{
+		Version:    vaultVersion,
+		KDF:        vaultKDF,
+		Iterations: v.iterations,
+		Salt:       v.salt,
+		Nonce:      nonce,
+		Data:       gcm.Seal(nil, nonce, plaintext, nil),
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// Temporary files are created with mode 0600
+	file, err := os.CreateTemp(filepath.Dir(v.path), ".tokens-*.vault")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), v.path)
+}
@@ -0,0 +1,125 @@
+package secret
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOpenVaultRoundTrip(t *testing.T) {
+	tests := []struct {
+		name       string
+		passphrase string
+		tokens     map[string]string
+	}{
+		{name: "single token", passphrase: "correct horse", tokens: map[string]string{"production": "token-1"}},
+		{name: "several tokens", passphrase: "pw", tokens: map[string]string{"production": "token-1", "staging": "token-2", "it's": "token-3"}},
+		{name: "passphrase with surrounding spaces", passphrase: "  spaced  ", tokens: map[string]string{"production": "token-1"}},
+		{name: "unicode passphrase", passphrase: "pässwörd 🔑", tokens: map[string]string{"production": "token-1"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "tokens.vault")
+			vault, err := OpenVault(path, test.passphrase)
+			if err != nil {
+				t.Fatalf("creating the vault: %v", err)
+			}
+			for project, token := range test.tokens {
+				ref, err := vault.Set(context.Background(), project, token)
+				if err != nil {
+					t.Fatalf("storing the token of %s: %v", project, err)
+				}
+				if ref != project {
+					t.Errorf("reference of %s = %q, want the project name", project, ref)
+				}
+			}
+
+			info, err := os.Stat(path)
+			if err != nil {
+				t.Fatalf("the vault wasn't written: %v", err)
+			}
+			if mode := info.Mode().Perm(); mode != 0600 {
+				t.Errorf("vault mode = %o, want 600", mode)
+			}
+
+			reopened, err := OpenVault(path, test.passphrase)
+			if err != nil {
+				t.Fatalf("reopening the vault: %v", err)
+			}
+			for project, want := range test.tokens {
+				got, err := reopened.Get(context.Background(), project)
+				if err != nil {
+					t.Errorf("reading the token of %s: %v", project, err)
+				} else if got != want {
+					t.Errorf("token of %s = %q, want %q", project, got, want)
+				}
+			}
+			if _, err := reopened.Get(context.Background(), "missing"); err == nil {
+				t.Error("reading a missing token succeeded")
+			}
+		})
+	}
+}
+
+func TestOpenVaultWrongPassphrase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tokens.vault")
+	vault, err := OpenVault(path, "secret")
+	if err != nil {
+		t.Fatalf("creating the vault: %v", err)
+	}
+	if _, err := vault.Set(context.Background(), "production", "token-1"); err != nil {
+		t.Fatalf("storing a token: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		passphrase string
+		wantErr    error
+	}{
+		{name: "other passphrase", passphrase: "other", wantErr: ErrWrongPassphrase},
+		{name: "different case", passphrase: "Secret", wantErr: ErrWrongPassphrase},
+		{name: "trailing space", passphrase: "secret ", wantErr: ErrWrongPassphrase},
+		{name: "leading space", passphrase: " secret", wantErr: ErrWrongPassphrase},
+		{name: "empty", passphrase: ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			opened, err := OpenVault(path, test.passphrase)
+			if err == nil {
+				t.Fatalf("opened the vault with %q: %v", test.passphrase, opened.tokens)
+			}
+			if test.wantErr != nil && !errors.Is(err, test.wantErr) {
+				t.Errorf("error = %v, want %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestVaultDelete(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tokens.vault")
+	vault, err := OpenVault(path, "secret")
+	if err != nil {
+		t.Fatalf("creating the vault: %v", err)
+	}
+	for _, project := range []string{"production", "staging"} {
+		if _, err := vault.Set(context.Background(), project, "token-"+project); err != nil {
+			t.Fatalf("storing the token of %s: %v", project, err)
+		}
+	}
+	if err := vault.Delete("production"); err != nil {
+		t.Fatalf("deleting a token: %v", err)
+	}
+
+	reopened, err := OpenVault(path, "secret")
+	if err != nil {
+		t.Fatalf("reopening the vault: %v", err)
+	}
+	if _, err := reopened.Get(context.Background(), "production"); err == nil {
+		t.Error("the deleted token is still in the vault")
+	}
+	if token, err := reopened.Get(context.Background(), "staging"); err != nil || token != "token-staging" {
+		t.Errorf("token of staging = %q, %v, want it kept", token, err)
+	}
+}
@@ -0,0 +1,281 @@
+package topology
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/grammeaway/lazyhetzner/internal/registry"
+	"github.com/grammeaway/lazyhetzner/internal/resource"
+	"github.com/grammeaway/lazyhetzner/internal/resource/floatingip"
+	"github.com/grammeaway/lazyhetzner/internal/resource/loadbalancer"
+	"github.com/grammeaway/lazyhetzner/internal/resource/network"
+	"github.com/grammeaway/lazyhetzner/internal/resource/server"
+	"github.com/grammeaway/lazyhetzner/internal/resource/volume"
+	"github.com/hetznercloud/hcloud-go/v2/hcloud"
+)
+
+// Node is an entry of the topology tree. Groups have no link, every other node links to the resource it shows.
+type Node struct {
+	Label    string
+	Link     registry.Link
+	Children []*Node
+}
+
+// IsGroup reports whether the node only groups other nodes
+func (n *Node) IsGroup() bool {
+	return n.Link.ID == 0
+}
+
+// Row is a node of the flattened tree, as it is shown on a single line
+type Row struct {
+	Node *Node
+	// Key identifies the node by its path from the root, as the same resource can show up in several places
+	Key string
+	// Prefix holds the tree lines leading up to the node
+	Prefix    string
+	Depth     int
+	Collapsed bool
+}
+
+// resources holds the loaded resources a topology is built from
+type resources struct {
+	servers       []*hcloud.Server
+	networks      []*hcloud.Network
+	loadBalancers []*hcloud.LoadBalancer
+	floatingIPs   []*hcloud.FloatingIP
+	volumes       []*hcloud.Volume
+}
+
+func collect(related registry.Related) resources {
+	var loaded resources
+	for _, item := range related(resource.ResourceServers) {
+		if serverItem, ok := item.(server.ServerItem); ok && serverItem.Server != nil {
+			loaded.servers = append(loaded.servers, serverItem.Server)
+		}
+	}
+	for _, item := range related(resource.ResourceNetworks) {
+		if networkItem, ok := item.(network.NetworkItem); ok && networkItem.Network != nil {
+			loaded.networks = append(loaded.networks, networkItem.Network)
+		}
+	}
+	for _, item := range related(resource.ResourceLoadBalancers) {
+		if lbItem, ok := item.(loadbalancer.LoadBalancerItem); ok && lbItem.Lb != nil {
+			loaded.loadBalancers = append(loaded.loadBalancers, lbItem.Lb)
+		}
+	}
+	for _, item := range related(resource.ResourceFloatingIPs) {
+		if floatingIPItem, ok := item.(floatingip.FloatingIPItem); ok && floatingIPItem.FloatingIP != nil {
+			loaded.floatingIPs = append(loaded.floatingIPs, floatingIPItem.FloatingIP)
+		}
+	}
+	for _, item := range related(resource.ResourceVolumes) {
+		if volumeItem, ok := item.(volume.VolumeItem); ok && volumeItem.Volume != nil {
+			loaded.volumes = append(loaded.volumes, volumeItem.Volume)
+		}
+	}
+	sort.SliceStable(loaded.servers, func(i, j int) bool { return loaded.servers[i].Name < loaded.servers[j].Name })
+	sort.SliceStable(loaded.networks, func(i, j int) bool { return loaded.networks[i].Name < loaded.networks[j].Name })
+	sort.SliceStable(loaded.loadBalancers, func(i, j int) bool { return loaded.loadBalancers[i].Name < loaded.loadBalancers[j].Name })
+	return loaded
+}
+
+// Build wires the loaded resources of a project into a tree: networks with their subnets and the
+// servers in each subnet, load balancers with their targets, and servers outside of any network.
+// Servers carry their floating IPs and volumes, and unattached floating IPs and volumes are grouped last.
+func Build(related registry.Related) []*Node {
+	loaded := collect(related)
+	serversByID := make(map[int64]*hcloud.Server, len(loaded.servers))
+	for _, s := range loaded.servers {
+		serversByID[s.ID] = s
+	}
+
+	roots := []*Node{}
+	if networks := loaded.networkNodes(); len(networks) > 0 {
+		roots = append(roots, &Node{Label: "Networks", Children: networks})
+	}
+	if loadBalancers := loaded.loadBalancerNodes(serversByID); len(loadBalancers) > 0 {
+		roots = append(roots, &Node{Label: "Load Balancers", Children: loadBalancers})
+	}
+	standalone := []*Node{}
+	for _, s := range loaded.servers {
+		if len(s.PrivateNet) == 0 {
+			standalone = append(standalone, loaded.serverNode(s, ""))
+		}
+	}
+	if len(standalone) > 0 {
+		roots = append(roots, &Node{Label: "Servers without private networks", Children: standalone})
+	}
+	if unattached := loaded.unattachedNodes(); len(unattached) > 0 {
+		roots = append(roots, &Node{Label: "Unattached", Children: unattached})
+	}
+	return roots
+}
+
+func (loaded resources) networkNodes() []*Node {
+	nodes := make([]*Node, 0, len(loaded.networks))
+	for _, n := range loaded.networks {
+		ipRange := "n/a"
+		if n.IPRange != nil {
+			ipRange = n.IPRange.String()
+		}
+		networkNode := &Node{
+			Label: fmt.Sprintf("🌐 %s (%s)", n.Name, ipRange),
+			Link:  registry.Link{ResourceType: resource.ResourceNetworks, ID: n.ID},
+		}
+		placed := map[int64]bool{}
+		for _, subnet := range n.Subnets {
+			if subnet.IPRange == nil {
+				continue
+			}
+			subnetNode := &Node{
+				Label: fmt.Sprintf("🧩 %s | %s", subnet.IPRange.String(), subnet.NetworkZone),
+				Link:  registry.Link{ResourceType: resource.ResourceNetworks, ID: n.ID, Part: resource.SubnetPart(subnet.IPRange.String())},
+			}
+			for _, s := range loaded.servers {
+				for _, privateNet := range s.PrivateNet {
+					if privateNet.Network == nil || privateNet.Network.ID != n.ID || !subnet.IPRange.Contains(privateNet.IP) {
+						continue
+					}
+					placed[s.ID] = true
+					subnetNode.Children = append(subnetNode.Children, loaded.serverNode(s, resource.FormatIP(privateNet.IP)))
+				}
+			}
+			networkNode.Children = append(networkNode.Children, subnetNode)
+		}
+		// Servers attached to the network with an IP outside of its subnets still belong to it
+		for _, s := range loaded.servers {
+			if placed[s.ID] {
+				continue
+			}
+			for _, privateNet := range s.PrivateNet {
+				if privateNet.Network != nil && privateNet.Network.ID == n.ID {
+					networkNode.Children = append(networkNode.Children, loaded.serverNode(s, resource.FormatIP(privateNet.IP)))
+				}
+			}
+		}
+		nodes = append(nodes, networkNode)
+	}
+	return nodes
+}
+
+// serverNode shows a server with the floating IPs and volumes attached to it
+func (loaded resources) serverNode(s *hcloud.Server, address string) *Node {
+	if address == "" {
+		address = resource.FormatIP(s.PublicNet.IPv4.IP)
+	}
+	node := &Node{
+		Label: fmt.Sprintf("🖥️ %s | %s | %s", s.Name, address, s.Status),
+		Link:  registry.Link{ResourceType: resource.ResourceServers, ID: s.ID},
+	}
+	for _, floatingIP := range loaded.floatingIPs {
+		if floatingIP.Server != nil && floatingIP.Server.ID == s.ID {
+			node.Children = append(node.Children, floatingIPNode(floatingIP))
+		}
+	}
+	for _, v := range loaded.volumes {
+		if v.Server != nil && v.Server.ID == s.ID {
+			node.Children = append(node.Children, volumeNode(v))
+		}
+	}
+	return node
+}
+
+func floatingIPNode(floatingIP *hcloud.FloatingIP) *Node {
+	label := fmt.Sprintf("📍 %s", resource.FormatIP(floatingIP.IP))
+	if floatingIP.Name != "" {
+		label = fmt.Sprintf("📍 %s | %s", floatingIP.Name, resource.FormatIP(floatingIP.IP))
+	}
+	return &Node{Label: label, Link: registry.Link{ResourceType: resource.ResourceFloatingIPs, ID: floatingIP.ID}}
+}
+
+func volumeNode(v *hcloud.Volume) *Node {
+	return &Node{
+		Label: fmt.Sprintf("💾 %s | %d GB", v.Name, v.Size),
+		Link:  registry.Link{ResourceType: resource.ResourceVolumes, ID: v.ID},
+	}
+}
+
+func (loaded resources) loadBalancerNodes(serversByID map[int64]*hcloud.Server) []*Node {
+	nodes := make([]*Node, 0, len(loaded.loadBalancers))
+	for _, lb := range loaded.loadBalancers {
+		lbNode := &Node{
+			Label: fmt.Sprintf("⚖️ %s | %s", lb.Name, resource.FormatIP(lb.PublicNet.IPv4.IP)),
+			Link:  registry.Link{ResourceType: resource.ResourceLoadBalancers, ID: lb.ID},
+		}
+		for _, target := range lb.Targets {
+			switch {
+			case target.Server != nil && target.Server.Server != nil:
+				lbNode.Children = append(lbNode.Children, targetServerNode(target.Server.Server, serversByID))
+			case target.LabelSelector != nil:
+				selectorNode := &Node{Label: fmt.Sprintf("🏷️ %s", target.LabelSelector.Selector)}
+				for _, resolved := range target.Targets {
+					if resolved.Server != nil && resolved.Server.Server != nil {
+						selectorNode.Children = append(selectorNode.Children, targetServerNode(resolved.Server.Server, serversByID))
+					}
+				}
+				lbNode.Children = append(lbNode.Children, selectorNode)
+			case target.IP != nil:
+				lbNode.Children = append(lbNode.Children, &Node{Label: fmt.Sprintf("🎯 %s", target.IP.IP)})
+			}
+		}
+		nodes = append(nodes, lbNode)
+	}
+	return nodes
+}
+
+// targetServerNode shows a server targeted by a load balancer. Targets only carry the server's ID,
+// so its name comes from the loaded servers.
+func targetServerNode(target *hcloud.Server, serversByID map[int64]*hcloud.Server) *Node {
+	name := strconv.FormatInt(target.ID, 10)
+	if loaded, ok := serversByID[target.ID]; ok {
+		name = loaded.Name
+	} else if target.Name != "" {
+		name = target.Name
+	}
+	return &Node{
+		Label: fmt.Sprintf("🖥️ %s", name),
+		Link:  registry.Link{ResourceType: resource.ResourceServers, ID: target.ID},
+	}
+}
+
+func (loaded resources) unattachedNodes() []*Node {
+	nodes := []*Node{}
+	for _, floatingIP := range loaded.floatingIPs {
+		if floatingIP.Server == nil {
+			nodes = append(nodes, floatingIPNode(floatingIP))
+		}
+	}
+	for _, v := range loaded.volumes {
+		if v.Server == nil {
+			nodes = append(nodes, volumeNode(v))
+		}
+	}
+	return nodes
+}
+
+// Flatten lays out the tree line by line, leaving out the children of collapsed nodes
+func Flatten(roots []*Node, collapsed map[string]bool) []Row {
+	rows := []Row{}
+	for i, root := range roots {
+		rows = flatten(rows, root, strconv.Itoa(i), "", "", 0, collapsed)
+	}
+	return rows
+}
+
+func flatten(rows []Row, node *Node, key, prefix, childPrefix string, depth int, collapsed map[string]bool) []Row {
+	isCollapsed := collapsed[key] && len(node.Children) > 0
+	rows = append(rows, Row{Node: node, Key: key, Prefix: prefix, Depth: depth, Collapsed: isCollapsed})
+	if isCollapsed {
+		return rows
+	}
+	for i, child := range node.Children {
+		childKey := fmt.Sprintf("%s/%d", key, i)
+		if i == len(node.Children)-1 {
+			rows = flatten(rows, child, childKey, childPrefix+"└─ ", childPrefix+"   ", depth+1, collapsed)
+		} else {
+			rows = flatten(rows, child, childKey, childPrefix+"├─ ", childPrefix+"│  ", depth+1, collapsed)
+		}
+	}
+	return rows
+}